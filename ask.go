@@ -6,34 +6,42 @@ import "reflect"
 
 // If implements a ternary operator that supports both boolean conditions and zero value checking.
 //   - If condition is bool type: returns trueVal if true, falseVal if false
-//   - If condition is error type: returns trueVal if error is non-nil (error state), falseVal if nil (success state)
-//   - If condition is non-bool type: returns trueVal if non-zero, falseVal if zero
+//   - If condition is error type: returns trueVal if error is non-nil (error state), falseVal if nil (success state).
+//     A typed nil such as (*MyErr)(nil) stored in an error counts as nil.
+//   - If condition is non-bool type: returns trueVal if non-zero, falseVal if zero.
+//     Types with an IsZero method decide for themselves, so an Ok Result is true.
 //
 // If 三目运算符 (支持布尔条件或值非空判断)
 //   - 若 condition 为 bool 类型：true 返回 trueVal，false 返回 falseVal
-//   - 若 condition 非 bool 类型：非零值返回 trueVal，零值返回 falseVal
+//   - 若 condition 为 error 类型：非 nil 返回 trueVal，nil（包括接口中的 typed nil）返回 falseVal
+//   - 若 condition 非 bool 类型：非零值返回 trueVal，零值返回 falseVal（Result 成功时为真）
 func If[T any, C any](condition C, trueVal, falseVal T) T {
+	if truthy(condition) {
+		return trueVal
+	}
+	return falseVal
+}
+
+// truthy reports whether condition selects the true branch of If, When and Cond.
+func truthy[C any](condition C) bool {
 	// Fast path for boolean conditions
 	if b, ok := any(condition).(bool); ok {
-		if b {
-			return trueVal
-		}
-		return falseVal
+		return b
 	}
 
-	// Special handling for error type (including typed nil)
+	// Funcs registered with RegisterZeroFunc take precedence over the error rule
+	if fn := zeroFuncs.lookup(any(condition)); fn != nil {
+		return !fn(any(condition))
+	}
+
+	// Special handling for error type (including typed nil):
+	// an error state is true, a nil error is false
 	if err, ok := any(condition).(error); ok {
-		if err != nil {
-			return trueVal // error state - return first value (error message)
-		}
-		return falseVal // success state (nil error) - return second value (success message)
+		return !IsNil(err)
 	}
 
 	// General zero value check
-	if !IsZero(condition) {
-		return trueVal
-	}
-	return falseVal
+	return !isZero(condition)
 }
 
 // Ifelse implements null coalescing operator (?:).
@@ -41,22 +49,39 @@ func If[T any, C any](condition C, trueVal, falseVal T) T {
 // Ifelse 空值合并运算符  问号冒号(?:)
 // 如果第一个值为零值或nil，则返回第二个值
 func Ifelse[T any](value, defaultVal T) T {
-	if !IsZero(value) {
+	if !isZero(value) {
 		return value
 	}
 	return defaultVal
 }
 
 // IsEmpty checks if a value is considered "empty" (more intuitive than zero for some types).
-// For strings, slices, maps, and channels, it checks if length is 0.
-// For other types, it falls back to IsZero.
-// IsZero 检查值是否为零值
-// 支持的类型包括：布尔、整数、浮点数、字符串、指针、切片、映射、通道、函数和接口
+// The checks are applied in this order, the first one that applies wins:
+//  0. a func registered for the type with RegisterEmptyFunc
+//  1. nil and nil pointers are empty
+//  2. built-in strings, slices and maps are empty when their length is 0
+//  3. an IsEmpty() bool method
+//  4. an Empty() bool method
+//  5. a Len() int method (e.g. *bytes.Buffer, *strings.Builder, *list.List) reporting 0
+//  6. slices, maps, channels and arrays of any type are empty when their length is 0
+//  7. anything else falls back to IsZero
+//
+// Methods declared on the pointer receiver are honored for values as well.
+//
+// IsEmpty 检查值是否为空，按以下顺序判断（先命中者生效）：
+// 注册的判断函数 → nil 及 nil 指针 → 内置字符串/切片/映射长度 → IsEmpty() 方法 → Empty() 方法
+// → Len() 方法 → 任意切片/映射/通道/数组长度 → 回退到 IsZero
 func IsEmpty(v any) bool {
 	if v == nil {
 		return true
 	}
 
+	// Funcs registered with RegisterEmptyFunc take precedence over the built-in rules
+	// 通过 RegisterEmptyFunc 注册的判断函数优先于内置规则
+	if fn := emptyFuncs.lookup(v); fn != nil {
+		return fn(v)
+	}
+
 	switch x := v.(type) {
 	case string:
 		return len(x) == 0
@@ -108,144 +133,198 @@ func IsEmpty(v any) bool {
 		return len(x) == 0
 	}
 
+	// Reflection fallback, compiled once per type and cached
+	// 反射处理其他类型，每种类型只编译一次检查函数并缓存
+	return emptyCheckers.get(v)(v)
+}
+
+// IsNil reports whether v is nil, including typed nils stored in an interface:
+// nil pointers, maps, slices, funcs, channels and interfaces all count as nil.
+// Unlike v == nil, IsNil(error((*MyErr)(nil))) is true.
+//
+// IsNil 判断值是否为 nil，能识别接口中保存的 typed nil（指针、映射、切片、函数、通道）
+func IsNil(v any) bool {
+	if v == nil {
+		return true
+	}
 	rv := reflect.ValueOf(v)
 	switch rv.Kind() {
-	case reflect.Slice, reflect.Map, reflect.Chan:
-		return rv.Len() == 0
-	case reflect.Array:
-		return rv.Len() == 0
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
 	default:
-		return IsZero(v)
+		return false
 	}
 }
 
 // IsZero checks if a value is the zero value for its type.
 // Optimized with type switches to avoid reflection for common types.
+// Types that declare an IsZero() bool method (on the value or pointer receiver),
+// such as time.Time, are checked with that method instead of their raw fields.
 // IsZero 检查值是否为零值 对于常见类型使用类型断言来优化性能，避免反射开销
 // 支持的类型包括：布尔、整数、浮点数、复数、字符串、错误、指针、切片、映射、数组和结构体
 // 对于复杂类型使用反射来判断是否为零值
 // 反射处理复杂类型，避免了对每个类型都写一个判断函数
 func IsZero(v any) bool {
+	if zero, ok := fastZero(v); ok {
+		return zero
+	}
+
+	// Reflection fallback for complex types, compiled once per type and cached
+	// 反射处理复杂类型，每种类型只编译一次检查函数并缓存
+	return slowZero(v)
+}
+
+// isZero is the generic entry point used by If, Ifelse, Coalesce and friends.
+// The value is boxed twice on purpose: the box passed to fastZero never escapes and
+// stays on the stack, and the escaping one is only created when the slow path needs it.
+func isZero[T any](v T) bool {
+	if zero, ok := fastZero(any(v)); ok {
+		return zero
+	}
+	return slowZero(any(v))
+}
+
+// fastZero handles nil and common built-in types without letting v escape.
+// ok is false when a registered func or the reflection fallback has to decide.
+func fastZero(v any) (zero, ok bool) {
 	if v == nil {
-		return true
+		return true, true
+	}
+
+	// Funcs registered with RegisterZeroFunc take precedence over the built-in rules
+	// 通过 RegisterZeroFunc 注册的判断函数优先于内置规则
+	if zeroFuncs.lookup(v) != nil {
+		return false, false
 	}
 
 	// Fast path for common types to avoid reflection overhead
 	// 常见类型的快速路径，避免反射开销
 	switch x := v.(type) {
 	case bool:
-		return !x
+		return !x, true
 	case int:
-		return x == 0
+		return x == 0, true
 	case int8:
-		return x == 0
+		return x == 0, true
 	case int16:
-		return x == 0
+		return x == 0, true
 	case int32:
-		return x == 0
+		return x == 0, true
 	case int64:
-		return x == 0
+		return x == 0, true
 	case uint:
-		return x == 0
+		return x == 0, true
 	case uint8:
-		return x == 0
+		return x == 0, true
 	case uint16:
-		return x == 0
+		return x == 0, true
 	case uint32:
-		return x == 0
+		return x == 0, true
 	case uint64:
-		return x == 0
+		return x == 0, true
 	case uintptr:
-		return x == 0
+		return x == 0, true
 	case float32:
-		return x == 0
+		return x == 0, true
 	case float64:
-		return x == 0
+		return x == 0, true
 	case complex64:
-		return x == 0
+		return x == 0, true
 	case complex128:
-		return x == 0
+		return x == 0, true
 	case string:
-		return x == ""
+		return x == "", true
 	case error:
-		return x == nil
+		return IsNil(x), true
 	// 添加切片和映射的快速路径
 	case []bool:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []int:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []int8:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []int16:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []int32:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []int64:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []uint:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []uint8:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []uint16:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []uint32:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []uint64:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []uintptr:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []float32:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []float64:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []complex64:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []complex128:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []string:
-		return len(x) == 0
+		return len(x) == 0, true
 	case []any:
-		return len(x) == 0
+		return len(x) == 0, true
 	case map[string]any:
-		return len(x) == 0
+		return len(x) == 0, true
 	case map[string]string:
-		return len(x) == 0
+		return len(x) == 0, true
 	case map[string]int:
-		return len(x) == 0
+		return len(x) == 0, true
 	case map[int]string:
-		return len(x) == 0
+		return len(x) == 0, true
 	case map[int]int:
-		return len(x) == 0
+		return len(x) == 0, true
 	}
+	return false, false
+}
 
-	// Reflection fallback for complex types
-	// 反射处理复杂类型
-	rv := reflect.ValueOf(v)
-	switch rv.Kind() {
-	case reflect.Ptr, reflect.Chan, reflect.Func, reflect.Interface:
-		return rv.IsNil()
-	case reflect.Slice, reflect.Map:
-		// Slice and map: nil or empty slice/map is considered zero
-		// 切片和映射：nil 或长度为 0 都认为是零值
-		return rv.IsNil() || rv.Len() == 0
-	case reflect.Array:
-		// Array: nil is not applicable, but an array with all zero values is considered zero
-		// 数组需要检查所有元素
-		return rv.IsZero()
-	case reflect.Struct:
-		// Struct: nil is not applicable, but a struct with all zero values is considered zero
-		// 结构体需要检查所有字段
-		return rv.IsZero()
-	default:
-		return rv.IsZero()
+// slowZero applies registered funcs and the cached per-type checkers.
+func slowZero(v any) bool {
+	if fn := zeroFuncs.lookup(v); fn != nil {
+		return fn(v)
 	}
+	return zeroCheckers.get(v)(v)
+}
+
+// zeroer is implemented by types that define their own notion of zero, such as time.Time.
+type zeroer interface {
+	IsZero() bool
+}
+
+// isEmptier, emptier and lener are the method sets IsEmpty looks for on collection types.
+type isEmptier interface {
+	IsEmpty() bool
+}
+
+type emptier interface {
+	Empty() bool
+}
+
+type lener interface {
+	Len() int
 }
 
+var (
+	zeroerType    = reflect.TypeOf((*zeroer)(nil)).Elem()
+	isEmptierType = reflect.TypeOf((*isEmptier)(nil)).Elem()
+	emptierType   = reflect.TypeOf((*emptier)(nil)).Elem()
+	lenerType     = reflect.TypeOf((*lener)(nil)).Elem()
+)
+
 // Coalesce returns the first non-zero value from the provided arguments.
-// Similar to SQL COALESCE function.
+// Similar to SQL COALESCE function and the || operator; see Nullish for ??.
 func Coalesce[T any](values ...T) T {
 	for _, v := range values {
-		if !IsZero(v) {
+		if !isZero(v) {
 			return v
 		}
 	}
@@ -258,3 +337,43 @@ func Coalesce[T any](values ...T) T {
 func Default[T any](value, defaultVal T) T {
 	return Ifelse(value, defaultVal)
 }
+
+// IfFunc is the lazy counterpart of If: only the function of the chosen branch is called.
+// The condition follows exactly the same bool/error/zero-value rules as If.
+// Use it when a branch is expensive or only valid under the condition (e.g. dereferencing a pointer).
+//
+// IfFunc 惰性三目运算符，仅执行被选中分支的函数，条件判断规则与 If 完全一致
+func IfFunc[T any, C any](condition C, trueFn, falseFn func() T) T {
+	return If(condition, trueFn, falseFn)()
+}
+
+// IfelseFunc is the lazy counterpart of Ifelse.
+// defaultFn is only called when value is zero.
+//
+// IfelseFunc 惰性空值合并，仅当 value 为零值时才调用 defaultFn
+func IfelseFunc[T any](value T, defaultFn func() T) T {
+	if !isZero(value) {
+		return value
+	}
+	return defaultFn()
+}
+
+// CoalesceFunc is the lazy counterpart of Coalesce.
+// Functions are called in order and evaluation stops at the first non-zero result.
+//
+// CoalesceFunc 惰性多值合并，按顺序调用函数，遇到第一个非零值即停止
+func CoalesceFunc[T any](fns ...func() T) T {
+	for _, fn := range fns {
+		if v := fn(); !isZero(v) {
+			return v
+		}
+	}
+	var zero T
+	return zero
+}
+
+// DefaultFunc is the lazy counterpart of Default.
+// This is an alias for IfelseFunc for better readability in some contexts.
+func DefaultFunc[T any](value T, defaultFn func() T) T {
+	return IfelseFunc(value, defaultFn)
+}
@@ -1,8 +1,13 @@
 package ask
 
 import (
+	"bytes"
+	"container/list"
 	"errors"
+	"net/netip"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestIf(t *testing.T) {
@@ -33,6 +38,76 @@ func TestIf(t *testing.T) {
 	}
 }
 
+type myErr struct{}
+
+func (*myErr) Error() string { return "my error" }
+
+// mayFail returns a typed nil on success, a classic Go pitfall.
+func mayFail(fail bool) error {
+	var err *myErr
+	if fail {
+		err = &myErr{}
+	}
+	return err
+}
+
+func TestIfTypedNilError(t *testing.T) {
+	if got := If(mayFail(false), "失败", "成功"); got != "成功" {
+		t.Errorf("If(typed nil error) = %v; want 成功", got)
+	}
+	if got := If(mayFail(true), "失败", "成功"); got != "失败" {
+		t.Errorf("If(non-nil error) = %v; want 失败", got)
+	}
+	if got := IfWith(Strict, mayFail(false), "失败", "成功"); got != "成功" {
+		t.Errorf("IfWith(typed nil error) = %v; want 成功", got)
+	}
+	if !IsZero(mayFail(false)) {
+		t.Errorf("IsZero(typed nil error) = false; want true")
+	}
+}
+
+func TestIsNil(t *testing.T) {
+	var (
+		nilPtr   *int
+		nilMap   map[string]int
+		nilSlice []int
+		nilFunc  func()
+		nilChan  chan int
+		nilErr   error = (*myErr)(nil)
+		nilIface any   = nilPtr
+	)
+
+	tests := []struct {
+		name   string
+		value  any
+		expect bool
+	}{
+		{"nil", nil, true},
+		{"nil pointer", nilPtr, true},
+		{"nil map", nilMap, true},
+		{"nil slice", nilSlice, true},
+		{"nil func", nilFunc, true},
+		{"nil chan", nilChan, true},
+		{"typed nil error", nilErr, true},
+		{"typed nil in any", nilIface, true},
+		{"empty slice", []int{}, false},
+		{"empty map", map[string]int{}, false},
+		{"pointer", new(int), false},
+		{"error", errors.New("error"), false},
+		{"zero int", 0, false},
+		{"empty string", "", false},
+		{"zero struct", struct{}{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNil(tt.value); got != tt.expect {
+				t.Errorf("IsNil(%v) = %v; want %v", tt.value, got, tt.expect)
+			}
+		})
+	}
+}
+
 func TestIfelse(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -95,6 +170,68 @@ func TestIsZero(t *testing.T) {
 	}
 }
 
+// decimal mimics decimal libraries whose zero value is not the Go zero value:
+// any coefficient of 0 is zero regardless of scale.
+type decimal struct {
+	coef  int64
+	scale int32
+}
+
+func (d decimal) IsZero() bool { return d.coef == 0 }
+
+// ptrDecimal declares IsZero on the pointer receiver.
+type ptrDecimal struct {
+	coef  int64
+	scale int32
+}
+
+func (d *ptrDecimal) IsZero() bool { return d.coef == 0 }
+
+func TestIsZeroMethod(t *testing.T) {
+	locOnly := time.Time{}.In(time.FixedZone("CST", 8*3600))
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		value  any
+		expect bool
+	}{
+		{"time zero", time.Time{}, true},
+		{"time with location only", locOnly, true},
+		{"time non-zero", now, false},
+		{"time pointer non-nil", &locOnly, false},
+		{"time pointer nil", (*time.Time)(nil), true},
+		{"netip zero", netip.Addr{}, true},
+		{"netip unspecified", netip.IPv4Unspecified(), false},
+		{"netip addr", netip.MustParseAddr("127.0.0.1"), false},
+		{"decimal zero with scale", decimal{coef: 0, scale: 2}, true},
+		{"decimal non-zero", decimal{coef: 150, scale: 2}, false},
+		{"pointer receiver zero", ptrDecimal{coef: 0, scale: 2}, true},
+		{"pointer receiver non-zero", ptrDecimal{coef: 1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsZero(tt.value); got != tt.expect {
+				t.Errorf("IsZero(%v) = %v; want %v", tt.value, got, tt.expect)
+			}
+		})
+	}
+
+	if got := If(locOnly, "set", "unset"); got != "unset" {
+		t.Errorf("If(location-only time) = %v; want unset", got)
+	}
+	if got := Ifelse(decimal{scale: 2}, decimal{coef: 100}); got.coef != 100 {
+		t.Errorf("Ifelse(zero decimal) = %v; want default", got)
+	}
+	if got := Coalesce(ptrDecimal{scale: 4}, ptrDecimal{coef: 7}); got.coef != 7 {
+		t.Errorf("Coalesce(zero decimal, 7) = %v; want 7", got)
+	}
+	if got := Coalesce(locOnly, now); !got.Equal(now) {
+		t.Errorf("Coalesce(location-only time, now) = %v; want %v", got, now)
+	}
+}
+
 func TestIsEmpty(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -125,6 +262,63 @@ func TestIsEmpty(t *testing.T) {
 	}
 }
 
+// stack reports its size through a pointer-receiver Len method.
+type stack struct{ items []int }
+
+func (s *stack) Len() int { return len(s.items) }
+
+// queue reports emptiness through Empty.
+type queue struct{ n int }
+
+func (q queue) Empty() bool { return q.n == 0 }
+
+// bag implements both IsEmpty and Len; IsEmpty takes precedence.
+type bag struct{ n int }
+
+func (b bag) IsEmpty() bool { return b.n <= 0 }
+func (b bag) Len() int      { return 1 }
+
+func TestIsEmptyMethods(t *testing.T) {
+	var filled bytes.Buffer
+	filled.WriteString("x")
+	var sb strings.Builder
+	l := list.New()
+
+	tests := []struct {
+		name   string
+		value  any
+		expect bool
+	}{
+		{"empty buffer", &bytes.Buffer{}, true},
+		{"filled buffer", &filled, false},
+		{"nil buffer", (*bytes.Buffer)(nil), true},
+		{"empty builder", &sb, true},
+		{"empty list", l, true},
+		{"list with element", func() *list.List { l := list.New(); l.PushBack(1); return l }(), false},
+		{"pointer receiver Len on value", stack{}, true},
+		{"pointer receiver Len non-empty", stack{items: []int{1}}, false},
+		{"Empty method", queue{}, true},
+		{"Empty method non-empty", queue{n: 1}, false},
+		{"IsEmpty wins over Len", bag{}, true},
+		{"IsEmpty wins over Len non-empty", bag{n: 2}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsEmpty(tt.value); got != tt.expect {
+				t.Errorf("IsEmpty(%v) = %v; want %v", tt.value, got, tt.expect)
+			}
+			if got := IsZeroWith(tt.value, Empty); got != tt.expect {
+				t.Errorf("IsZeroWith(%v, Empty) = %v; want %v", tt.value, got, tt.expect)
+			}
+		})
+	}
+
+	if got := IfWith(Empty, &bytes.Buffer{}, "has data", "no data"); got != "no data" {
+		t.Errorf("IfWith(Empty, empty buffer) = %v; want no data", got)
+	}
+}
+
 func TestCoalesce(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -166,6 +360,88 @@ func TestDefault(t *testing.T) {
 	}
 }
 
+func TestIfFunc(t *testing.T) {
+	tests := []struct {
+		name   string
+		cond   any
+		expect string
+	}{
+		{"bool true", true, "yes"},
+		{"bool false", false, "no"},
+		{"non-empty string", "x", "yes"},
+		{"empty string", "", "no"},
+		{"nil pointer", (*int)(nil), "no"},
+		{"non-nil error", errors.New("error"), "yes"},
+		{"nil error", error(nil), "no"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			yes := func() string { calls++; return "yes" }
+			no := func() string { calls++; return "no" }
+			if got := IfFunc(tt.cond, yes, no); got != tt.expect {
+				t.Errorf("IfFunc(%v) = %v; want %v", tt.cond, got, tt.expect)
+			}
+			if calls != 1 {
+				t.Errorf("IfFunc(%v) called %d branches; want 1", tt.cond, calls)
+			}
+		})
+	}
+}
+
+func TestIfFuncNilSafe(t *testing.T) {
+	type order struct{ paid bool }
+	var o *order
+	got := IfFunc(o == nil, func() string { return "missing" }, func() string {
+		return If(o.paid, "paid", "unpaid")
+	})
+	if got != "missing" {
+		t.Errorf("IfFunc = %v; want missing", got)
+	}
+}
+
+func TestIfelseFunc(t *testing.T) {
+	called := false
+	fallback := func() string { called = true; return "default" }
+
+	if got := IfelseFunc("hello", fallback); got != "hello" || called {
+		t.Errorf("IfelseFunc(hello) = %v, called = %v; want hello, false", got, called)
+	}
+	if got := IfelseFunc("", fallback); got != "default" || !called {
+		t.Errorf("IfelseFunc('') = %v, called = %v; want default, true", got, called)
+	}
+}
+
+func TestCoalesceFunc(t *testing.T) {
+	var calls []string
+	val := func(s string) func() string {
+		return func() string {
+			calls = append(calls, s)
+			return s
+		}
+	}
+
+	if got := CoalesceFunc(val(""), val("hello"), val("world")); got != "hello" {
+		t.Errorf("CoalesceFunc = %v; want hello", got)
+	}
+	if len(calls) != 2 {
+		t.Errorf("CoalesceFunc evaluated %v; want it to stop after hello", calls)
+	}
+	if got := CoalesceFunc[string](); got != "" {
+		t.Errorf("CoalesceFunc() = %v; want empty", got)
+	}
+}
+
+func TestDefaultFunc(t *testing.T) {
+	if got := DefaultFunc(0, func() int { return 8080 }); got != 8080 {
+		t.Errorf("DefaultFunc(0) = %v; want 8080", got)
+	}
+	if got := DefaultFunc(3000, func() int { panic("must not be called") }); got != 3000 {
+		t.Errorf("DefaultFunc(3000) = %v; want 3000", got)
+	}
+}
+
 // Example tests for documentation
 func ExampleIf() {
 	score := 85
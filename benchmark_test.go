@@ -2,6 +2,7 @@ package ask
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -207,6 +208,224 @@ func BenchmarkIsEmptyStringSliceNative(b *testing.B) {
 	}
 }
 
+func BenchmarkIfFunc(b *testing.B) {
+	yes := func() string { return "yes" }
+	no := func() string { return "no" }
+	for i := 0; i < b.N; i++ {
+		_ = IfFunc(true, yes, no)
+	}
+}
+
+func BenchmarkIfelseFunc(b *testing.B) {
+	str := "hello"
+	fallback := func() string { return "default" }
+	for i := 0; i < b.N; i++ {
+		_ = IfelseFunc(str, fallback)
+	}
+}
+
+func BenchmarkCoalesceFunc4(b *testing.B) {
+	empty := func() string { return "" }
+	fallback := func() string { return "default" }
+	for i := 0; i < b.N; i++ {
+		_ = CoalesceFunc(empty, empty, empty, fallback)
+	}
+}
+
+func BenchmarkDefaultFunc(b *testing.B) {
+	fallback := func() int { return 8080 }
+	for i := 0; i < b.N; i++ {
+		_ = DefaultFunc(0, fallback)
+	}
+}
+
+// Struct and array conditions: the any-based API boxes the value,
+// the comparable variants compare against the zero value without allocating.
+type benchStruct struct {
+	ID    int64
+	Score float64
+	Name  string
+	Flags [4]bool
+}
+
+func BenchmarkIsZeroStruct(b *testing.B) {
+	v := benchStruct{ID: 1, Name: "bench"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IsZero(v)
+	}
+}
+
+func BenchmarkIsZeroOfStruct(b *testing.B) {
+	v := benchStruct{ID: 1, Name: "bench"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IsZeroOf(v)
+	}
+}
+
+func BenchmarkIsZeroArray(b *testing.B) {
+	v := [16]int64{15: 1}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IsZero(v)
+	}
+}
+
+func BenchmarkIsZeroOfArray(b *testing.B) {
+	v := [16]int64{15: 1}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IsZeroOf(v)
+	}
+}
+
+func BenchmarkIfStruct(b *testing.B) {
+	v := benchStruct{ID: 1, Name: "bench"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = If(v, "yes", "no")
+	}
+}
+
+func BenchmarkIfZeroStruct(b *testing.B) {
+	v := benchStruct{ID: 1, Name: "bench"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IfZero(v, "no", "yes")
+	}
+}
+
+func BenchmarkIfZeroArray(b *testing.B) {
+	v := [16]int64{15: 1}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IfZero(v, "no", "yes")
+	}
+}
+
+func BenchmarkIfNil(b *testing.B) {
+	v := &benchStruct{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IfNil(v, "no", "yes")
+	}
+}
+
+func BenchmarkCoalesceStruct4(b *testing.B) {
+	v := benchStruct{ID: 1}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Coalesce(benchStruct{}, benchStruct{}, benchStruct{}, v)
+	}
+}
+
+func BenchmarkCoalesceOfStruct4(b *testing.B) {
+	v := benchStruct{ID: 1}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = CoalesceOf(benchStruct{}, benchStruct{}, benchStruct{}, v)
+	}
+}
+
+// Cached per-type checkers against the per-call reflection path they replace.
+// isZeroReflect is the reflection fallback IsZero used before checkers were cached.
+func isZeroReflect(v any) bool {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		if rv.Type().Implements(zeroerType) {
+			return rv.Interface().(zeroer).IsZero()
+		}
+		if reflect.PointerTo(rv.Type()).Implements(zeroerType) {
+			p := reflect.New(rv.Type())
+			p.Elem().Set(rv)
+			return p.Interface().(zeroer).IsZero()
+		}
+	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Chan, reflect.Func, reflect.Interface:
+		return rv.IsNil()
+	case reflect.Slice, reflect.Map:
+		return rv.IsNil() || rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+type benchFlat struct {
+	Port    int64
+	Workers int64
+	Timeout int64
+	Flags   [8]bool
+}
+
+type benchNamed []int
+
+func benchCheckers(b *testing.B, fn func(any) bool) {
+	values := []any{
+		benchFlat{Flags: [8]bool{7: true}},
+		benchStruct{ID: 1, Name: "bench"},
+		[16]int64{15: 1},
+		benchNamed{1},
+		decimal{coef: 1},
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range values {
+			_ = fn(v)
+		}
+	}
+}
+
+func BenchmarkIsZeroCached(b *testing.B) {
+	benchCheckers(b, IsZero)
+}
+
+func BenchmarkIsZeroReflect(b *testing.B) {
+	benchCheckers(b, isZeroReflect)
+}
+
+func BenchmarkIsZeroFlatStruct(b *testing.B) {
+	var v any = benchFlat{Flags: [8]bool{7: true}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IsZero(v)
+	}
+}
+
+func BenchmarkIsZeroFlatStructReflect(b *testing.B) {
+	var v any = benchFlat{Flags: [8]bool{7: true}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = isZeroReflect(v)
+	}
+}
+
+func BenchmarkIsZeroMethod(b *testing.B) {
+	var v any = decimal{coef: 1}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IsZero(v)
+	}
+}
+
+func BenchmarkIsZeroMethodReflect(b *testing.B) {
+	var v any = decimal{coef: 1}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = isZeroReflect(v)
+	}
+}
+
+func BenchmarkIsEmptyMethod(b *testing.B) {
+	var v any = bag{n: 1}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IsEmpty(v)
+	}
+}
+
 // Comparative benchmarks for different approaches
 func BenchmarkComplexCondition_Ask(b *testing.B) {
 	user := &struct {
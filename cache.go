@@ -0,0 +1,234 @@
+package ask
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// checker reports whether a value of one specific dynamic type is zero (or empty).
+// Checkers are compiled once per type and cached, so the reflection work of
+// inspecting kinds, method sets and memory layout is paid only on the first call.
+//
+// checker 针对某一具体类型的零值（或空值）判断函数，每种类型只编译一次并缓存
+type checker func(v any) bool
+
+// checkerCache maps an interface type word to its compiled checker.
+// Reads are a lock-free load of an immutable map; a miss compiles the checker
+// and publishes a copy of the map, which happens once per type.
+type checkerCache struct {
+	mu      sync.Mutex
+	m       atomic.Pointer[map[unsafe.Pointer]checker]
+	compile func(reflect.Type) checker
+}
+
+// get returns the checker for the dynamic type of v, compiling it on first use.
+func (c *checkerCache) get(v any) checker {
+	key := typeOf(v)
+	if m := c.m.Load(); m != nil {
+		if fn, ok := (*m)[key]; ok {
+			return fn
+		}
+	}
+	return c.add(key, reflect.TypeOf(v))
+}
+
+func (c *checkerCache) add(key unsafe.Pointer, t reflect.Type) checker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	old := c.m.Load()
+	if old != nil {
+		if fn, ok := (*old)[key]; ok {
+			return fn
+		}
+	}
+	fn := c.compile(t)
+	m := make(map[unsafe.Pointer]checker, 1+lenOf(old))
+	if old != nil {
+		for k, v := range *old {
+			m[k] = v
+		}
+	}
+	m[key] = fn
+	c.m.Store(&m)
+	return fn
+}
+
+func lenOf(m *map[unsafe.Pointer]checker) int {
+	if m == nil {
+		return 0
+	}
+	return len(*m)
+}
+
+var (
+	zeroCheckers  = &checkerCache{compile: compileZero}
+	emptyCheckers = &checkerCache{compile: compileEmpty}
+)
+
+// compileZero builds the IsZero rule for values whose dynamic type is t.
+func compileZero(t reflect.Type) checker {
+	// Non-nil pointers are always non-zero; the interface data word is the pointer itself
+	// 非 nil 指针始终视为非零值
+	if t.Kind() == reflect.Ptr {
+		return func(v any) bool {
+			return dataOf(v) == nil
+		}
+	}
+
+	// Types with their own IsZero method (time.Time, decimals, ...) decide for themselves
+	// 自定义 IsZero 方法优先（包括指针接收者）
+	if c := methodChecker(t, zeroerType, func(z zeroer) bool { return z.IsZero() }); c != nil {
+		return c
+	}
+
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return func(v any) bool {
+			return reflect.ValueOf(v).IsNil()
+		}
+	case reflect.Slice, reflect.Map:
+		// Slice and map: nil or empty slice/map is considered zero
+		// 切片和映射：nil 或长度为 0 都认为是零值
+		return func(v any) bool {
+			return reflect.ValueOf(v).Len() == 0
+		}
+	case reflect.Array, reflect.Struct:
+		// Pointer-free types without padding are zero exactly when all their bytes are zero
+		// 无指针且无填充的类型直接比较内存
+		if isFlat(t) {
+			return memZero(t.Size())
+		}
+		// Otherwise every element or field has to be checked
+		// 否则需要检查所有元素或字段
+		return func(v any) bool {
+			return reflect.ValueOf(v).IsZero()
+		}
+	default:
+		return func(v any) bool {
+			return reflect.ValueOf(v).IsZero()
+		}
+	}
+}
+
+// compileEmpty builds the IsEmpty rule for values whose dynamic type is t.
+// See IsEmpty for the order of precedence.
+func compileEmpty(t reflect.Type) checker {
+	// Collection types report their own emptiness
+	// 自定义集合类型通过方法报告是否为空
+	method := methodChecker(t, isEmptierType, func(e isEmptier) bool { return e.IsEmpty() })
+	if method == nil {
+		method = methodChecker(t, emptierType, func(e emptier) bool { return e.Empty() })
+	}
+	if method == nil {
+		method = methodChecker(t, lenerType, func(l lener) bool { return l.Len() == 0 })
+	}
+
+	switch {
+	case t.Kind() == reflect.Ptr && method != nil:
+		return func(v any) bool {
+			return dataOf(v) == nil || method(v)
+		}
+	case method != nil:
+		return method
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Chan, reflect.Array:
+		return func(v any) bool {
+			return reflect.ValueOf(v).Len() == 0
+		}
+	default:
+		return IsZero
+	}
+}
+
+// methodChecker compiles a call to the method described by the interface type it.
+// Methods declared on the pointer receiver are reached through an addressable copy.
+// It returns nil if neither t nor *t implements it.
+func methodChecker[I any](t, it reflect.Type, call func(I) bool) checker {
+	if t.Implements(it) {
+		return func(v any) bool {
+			return call(v.(I))
+		}
+	}
+	if t.Kind() != reflect.Ptr && reflect.PointerTo(t).Implements(it) {
+		return func(v any) bool {
+			p := reflect.New(t)
+			p.Elem().Set(reflect.ValueOf(v))
+			return call(p.Interface().(I))
+		}
+	}
+	return nil
+}
+
+// isFlat reports whether t contains no pointers and no padding, so its zero value
+// is exactly the all-zero byte pattern and nothing else. Floats are excluded
+// because -0 compares equal to 0 without being all-zero bits.
+func isFlat(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	case reflect.Array:
+		return isFlat(t.Elem())
+	case reflect.Struct:
+		var size uintptr
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Name == "_" || !isFlat(f.Type) {
+				return false
+			}
+			size += f.Type.Size()
+		}
+		return size == t.Size()
+	default:
+		return false
+	}
+}
+
+// zeroBlock is compared against the memory of flat values, one block at a time.
+var zeroBlock = strings.Repeat("\x00", 1024)
+
+// memZero returns a checker that compares size bytes of the value against zero.
+func memZero(size uintptr) checker {
+	if size == 0 {
+		return func(any) bool { return true }
+	}
+	if size <= uintptr(len(zeroBlock)) {
+		zero := zeroBlock[:size]
+		return func(v any) bool {
+			return unsafe.String((*byte)(dataOf(v)), size) == zero
+		}
+	}
+	return func(v any) bool {
+		p := dataOf(v)
+		for n := size; n > 0; {
+			chunk := n
+			if chunk > uintptr(len(zeroBlock)) {
+				chunk = uintptr(len(zeroBlock))
+			}
+			if unsafe.String((*byte)(p), chunk) != zeroBlock[:chunk] {
+				return false
+			}
+			p = unsafe.Add(p, chunk)
+			n -= chunk
+		}
+		return true
+	}
+}
+
+// typeOf returns the type word of an interface value, which uniquely identifies its dynamic type.
+func typeOf(v any) unsafe.Pointer {
+	return (*[2]unsafe.Pointer)(unsafe.Pointer(&v))[0]
+}
+
+// dataOf returns the data word of an interface value: the pointer itself for
+// pointer types, and a pointer to the boxed copy for everything else.
+func dataOf(v any) unsafe.Pointer {
+	return (*[2]unsafe.Pointer)(unsafe.Pointer(&v))[1]
+}
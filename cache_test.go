@@ -0,0 +1,119 @@
+package ask
+
+import (
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type flatConfig struct {
+	Port    int64
+	Workers int32
+	Level   int16
+	Debug   [2]bool
+}
+
+type floatConfig struct {
+	Ratio float64
+}
+
+type paddedConfig struct {
+	Debug bool
+	Port  int64
+}
+
+type mixedConfig struct {
+	Host    string
+	Port    int
+	Tags    []string
+	Timeout time.Duration
+}
+
+type blankField struct {
+	A int64
+	_ int64
+}
+
+func TestIsFlat(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		expect bool
+	}{
+		{"flat struct", flatConfig{}, true},
+		{"int array", [4]int{}, true},
+		{"empty struct", struct{}{}, true},
+		{"padded struct", paddedConfig{}, false},
+		{"float struct", floatConfig{}, false},
+		{"struct with string", mixedConfig{}, false},
+		{"pointer array", [2]*int{}, false},
+		{"blank field", blankField{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isFlat(reflect.TypeOf(tt.value)); got != tt.expect {
+				t.Errorf("isFlat(%T) = %v; want %v", tt.value, got, tt.expect)
+			}
+		})
+	}
+}
+
+// TestCachedCheckersMatchReflect verifies the compiled checkers agree with
+// reflect.Value.IsZero for structs and arrays, including floats and large values.
+func TestCachedCheckersMatchReflect(t *testing.T) {
+	var big [300]int64
+	bigSet := big
+	bigSet[299] = 1
+
+	values := []any{
+		flatConfig{},
+		flatConfig{Port: 1},
+		flatConfig{Debug: [2]bool{1: true}},
+		floatConfig{},
+		floatConfig{Ratio: math.Copysign(0, -1)},
+		floatConfig{Ratio: math.NaN()},
+		paddedConfig{},
+		paddedConfig{Debug: true},
+		mixedConfig{},
+		mixedConfig{Timeout: time.Second},
+		[4]int{},
+		[4]int{0, 0, 0, 1},
+		big,
+		bigSet,
+		struct{}{},
+		[0]int{},
+	}
+
+	for _, v := range values {
+		want := reflect.ValueOf(v).IsZero()
+		for i := 0; i < 2; i++ { // the second round hits the cache
+			if got := IsZero(v); got != want {
+				t.Errorf("IsZero(%T %v) = %v; want %v", v, v, got, want)
+			}
+		}
+	}
+}
+
+func TestCachedCheckersConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if IsZero(flatConfig{Port: int64(i + 1)}) {
+					t.Error("IsZero(non-zero flatConfig) = true")
+					return
+				}
+				if !IsEmpty(mixedConfig{}) {
+					t.Error("IsEmpty(zero mixedConfig) = false")
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,53 @@
+package ask
+
+// The functions in this file are constrained to comparable types so the condition
+// never has to be boxed into an interface. They compile down to a plain == against
+// the zero value, can be inlined, and do not allocate, which makes them suitable
+// for hot loops. The trade-off is that they use the Go zero value only:
+// IsZero methods, empty-but-non-nil containers and registered checkers are not consulted.
+//
+// 本文件中的函数约束为 comparable 类型，条件无需装箱为 interface，
+// 可被内联且零内存分配，适合热点循环；仅按 Go 语言零值判断，不会调用 IsZero 等方法。
+
+// IsZeroOf reports whether v equals the zero value of T.
+//
+// IsZeroOf 判断 v 是否等于类型 T 的零值（无反射、无分配）
+func IsZeroOf[T comparable](v T) bool {
+	var zero T
+	return v == zero
+}
+
+// CoalesceOf returns the first argument that is not the zero value of T,
+// or the zero value if all arguments are zero. It behaves like cmp.Or.
+//
+// CoalesceOf 返回第一个不等于零值的参数，行为与 cmp.Or 一致
+func CoalesceOf[T comparable](values ...T) T {
+	var zero T
+	for _, v := range values {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
+
+// IfZero returns zeroVal if condition equals the zero value of C, otherwise nonZeroVal.
+//
+// IfZero 条件为零值时返回 zeroVal，否则返回 nonZeroVal
+func IfZero[C comparable, T any](condition C, zeroVal, nonZeroVal T) T {
+	var zero C
+	if condition == zero {
+		return zeroVal
+	}
+	return nonZeroVal
+}
+
+// IfNil returns nilVal if p is nil, otherwise nonNilVal.
+//
+// IfNil 指针为 nil 时返回 nilVal，否则返回 nonNilVal
+func IfNil[P any, T any](p *P, nilVal, nonNilVal T) T {
+	if p == nil {
+		return nilVal
+	}
+	return nonNilVal
+}
@@ -0,0 +1,80 @@
+package ask
+
+import "testing"
+
+type point struct {
+	X, Y int
+	Tag  string
+}
+
+func TestIsZeroOf(t *testing.T) {
+	if !IsZeroOf(0) || IsZeroOf(1) {
+		t.Errorf("IsZeroOf(int) wrong")
+	}
+	if !IsZeroOf("") || IsZeroOf("a") {
+		t.Errorf("IsZeroOf(string) wrong")
+	}
+	if !IsZeroOf(point{}) || IsZeroOf(point{Y: 1}) {
+		t.Errorf("IsZeroOf(struct) wrong")
+	}
+	if !IsZeroOf([4]int{}) || IsZeroOf([4]int{0, 0, 0, 1}) {
+		t.Errorf("IsZeroOf(array) wrong")
+	}
+	if !IsZeroOf[*int](nil) || IsZeroOf(new(int)) {
+		t.Errorf("IsZeroOf(pointer) wrong")
+	}
+}
+
+func TestCoalesceOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []point
+		expect point
+	}{
+		{"first non-zero", []point{{}, {X: 1}, {X: 2}}, point{X: 1}},
+		{"all zero", []point{{}, {}}, point{}},
+		{"none", nil, point{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CoalesceOf(tt.values...); got != tt.expect {
+				t.Errorf("CoalesceOf(%v) = %v; want %v", tt.values, got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestIfZero(t *testing.T) {
+	if got := IfZero(point{}, "unset", "set"); got != "unset" {
+		t.Errorf("IfZero(zero struct) = %v; want unset", got)
+	}
+	if got := IfZero([2]int{1, 0}, "unset", "set"); got != "set" {
+		t.Errorf("IfZero(non-zero array) = %v; want set", got)
+	}
+}
+
+func TestIfNil(t *testing.T) {
+	var p *point
+	if got := IfNil(p, "nil", "ptr"); got != "nil" {
+		t.Errorf("IfNil(nil) = %v; want nil", got)
+	}
+	if got := IfNil(&point{}, "nil", "ptr"); got != "ptr" {
+		t.Errorf("IfNil(ptr) = %v; want ptr", got)
+	}
+}
+
+func TestComparableNoAllocs(t *testing.T) {
+	s := point{X: 1, Tag: "a"}
+	a := [16]int64{15: 1}
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = IsZeroOf(s)
+		_ = IsZeroOf(a)
+		_ = CoalesceOf(point{}, s)
+		_ = IfZero(a, "zero", "non-zero")
+		_ = IfNil(&s, "nil", "ptr")
+	})
+	if allocs != 0 {
+		t.Errorf("comparable fast paths allocated %v times per run; want 0", allocs)
+	}
+}
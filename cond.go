@@ -0,0 +1,138 @@
+package ask
+
+// Chain is a multi-branch conditional started by When or WhenFunc. Each branch is
+// tried in order and the first one whose condition holds is chosen; the values of
+// the other branches are never computed when given as funcs:
+//
+//	status := ask.When(order.IsCancelled, "cancelled").
+//		ElseIf(!order.IsPaid, "awaiting payment").
+//		ElseIfFunc(order.IsShipped, order.TrackingInfo).
+//		Else("preparing")
+//
+// Conditions follow exactly the same bool/error/zero-value rules as If. They are
+// ordinary Go arguments, so each one is still evaluated when its method is called;
+// guard expressions that are only valid under an earlier condition (such as
+// dereferencing a pointer) before building the chain. Chain is a small value type
+// and does not allocate for bool conditions.
+//
+// Chain 多分支条件链，由 When 或 WhenFunc 开始，条件规则与 If 完全一致
+type Chain[T any] struct {
+	val  T
+	done bool
+}
+
+// When starts a Chain whose first branch yields val if condition holds.
+//
+// When 开始多分支条件链，条件成立时结果为 val
+func When[T any, C any](condition C, val T) Chain[T] {
+	if truthy(condition) {
+		return Chain[T]{val: val, done: true}
+	}
+	return Chain[T]{}
+}
+
+// WhenFunc is the lazy counterpart of When: fn is only called if condition holds.
+//
+// WhenFunc 惰性版本的 When，仅当条件成立时才调用 fn
+func WhenFunc[T any, C any](condition C, fn func() T) Chain[T] {
+	if truthy(condition) {
+		return Chain[T]{val: fn(), done: true}
+	}
+	return Chain[T]{}
+}
+
+// ElseIf adds a branch yielding val if no earlier branch was chosen and condition holds.
+// Once a branch was chosen, condition is not inspected.
+//
+// ElseIf 前面的分支均未命中且条件成立时，结果为 val
+func (c Chain[T]) ElseIf(condition any, val T) Chain[T] {
+	if c.done || !truthy(condition) {
+		return c
+	}
+	return Chain[T]{val: val, done: true}
+}
+
+// ElseIfFunc is the lazy counterpart of ElseIf: fn is only called if its branch is chosen.
+//
+// ElseIfFunc 惰性版本的 ElseIf，仅当该分支被选中时才调用 fn
+func (c Chain[T]) ElseIfFunc(condition any, fn func() T) Chain[T] {
+	if c.done || !truthy(condition) {
+		return c
+	}
+	return Chain[T]{val: fn(), done: true}
+}
+
+// Else ends the chain, returning the value of the chosen branch, or val if none was chosen.
+//
+// Else 结束条件链，没有分支命中时返回 val
+func (c Chain[T]) Else(val T) T {
+	if c.done {
+		return c.val
+	}
+	return val
+}
+
+// ElseFunc is the lazy counterpart of Else: fn is only called if no branch was chosen.
+//
+// ElseFunc 惰性版本的 Else，仅当没有分支命中时才调用 fn
+func (c Chain[T]) ElseFunc(fn func() T) T {
+	if c.done {
+		return c.val
+	}
+	return fn()
+}
+
+// Value ends the chain, returning the value of the chosen branch and whether one was chosen.
+//
+// Value 结束条件链，返回命中分支的值以及是否有分支命中
+func (c Chain[T]) Value() (T, bool) {
+	return c.val, c.done
+}
+
+// Case is one branch of Cond, built with CaseOf or CaseFunc. Its condition is
+// checked when the Case is built, so Cases of different condition types can be mixed.
+//
+// Case Cond 的一个分支，由 CaseOf 或 CaseFunc 构造
+type Case[T any] struct {
+	ok  bool
+	val T
+	fn  func() T
+}
+
+// CaseOf returns a Case yielding val if condition holds, under the rules of If.
+//
+// CaseOf 构造条件成立时结果为 val 的分支
+func CaseOf[T any, C any](condition C, val T) Case[T] {
+	return Case[T]{ok: truthy(condition), val: val}
+}
+
+// CaseFunc returns a Case whose value fn is only computed if Cond chooses it.
+//
+// CaseFunc 构造惰性分支，仅当该分支被选中时才调用 fn
+func CaseFunc[T any, C any](condition C, fn func() T) Case[T] {
+	return Case[T]{ok: truthy(condition), fn: fn}
+}
+
+// Cond returns the value of the first Case whose condition holds, or the zero value
+// of T if none does. Add a final CaseOf(true, def) for a default:
+//
+//	priority := ask.Cond(
+//		ask.CaseOf(user.IsVIP, "high"),
+//		ask.CaseOf(order.Amount > 1000, "medium"),
+//		ask.CaseOf(true, "normal"),
+//	)
+//
+// Cond 返回第一个条件成立的分支的值，都不成立时返回零值
+func Cond[T any](cases ...Case[T]) T {
+	for _, c := range cases {
+		if !c.ok {
+			continue
+		}
+		if c.fn != nil {
+			return c.fn()
+		}
+		return c.val
+	}
+	var zero T
+	return zero
+}
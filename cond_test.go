@@ -0,0 +1,124 @@
+package ask
+
+import (
+	"errors"
+	"testing"
+)
+
+func grade(score int) string {
+	return When(score >= 90, "A").
+		ElseIf(score >= 80, "B").
+		ElseIf(score >= 70, "C").
+		Else("F")
+}
+
+func TestWhen(t *testing.T) {
+	for score, want := range map[int]string{95: "A", 90: "A", 85: "B", 70: "C", 10: "F"} {
+		if got := grade(score); got != want {
+			t.Errorf("grade(%d) = %q, want %q", score, got, want)
+		}
+	}
+
+	// Conditions follow the rules of If
+	var err error
+	var typedNil *resultErr
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"error", When(errors.New("boom"), "failed").Else("ok"), "failed"},
+		{"nil error", When(err, "failed").Else("ok"), "ok"},
+		{"typed nil error", When[string](error(typedNil), "failed").Else("ok"), "ok"},
+		{"string", When("", "set").ElseIf("x", "x").Else("none"), "x"},
+		{"pointer", When((*int)(nil), "ptr").ElseIf([]int{}, "slice").Else("none"), "none"},
+		{"result", When(Err[int](errors.New("boom")), "ok").ElseIf(Ok(0), "ok(0)").Else("none"), "ok(0)"},
+		{"first wins", When(true, "first").ElseIf(true, "second").Else("third"), "first"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if v, ok := When(false, 1).ElseIf(0, 2).Value(); ok || v != 0 {
+		t.Errorf("Value() = %d, %v; want 0, false", v, ok)
+	}
+	if v, ok := When(false, 1).ElseIf(3, 2).Value(); !ok || v != 2 {
+		t.Errorf("Value() = %d, %v; want 2, true", v, ok)
+	}
+}
+
+func TestWhenLazy(t *testing.T) {
+	var called []string
+	fn := func(name string) func() string {
+		return func() string { called = append(called, name); return name }
+	}
+
+	got := WhenFunc(false, fn("when")).
+		ElseIfFunc(true, fn("chosen")).
+		ElseIfFunc(true, fn("later")).
+		ElseFunc(fn("else"))
+	if got != "chosen" || len(called) != 1 {
+		t.Errorf("chain = %q, called %v; want only chosen", got, called)
+	}
+
+	called = nil
+	if got := WhenFunc(0, fn("when")).ElseFunc(fn("else")); got != "else" || len(called) != 1 {
+		t.Errorf("chain = %q, called %v; want only else", got, called)
+	}
+
+	var order *struct{ Paid bool }
+	status := When(order == nil, "missing").
+		ElseIfFunc(order != nil, func() string { return If(order.Paid, "paid", "unpaid") }).
+		Else("unknown")
+	if status != "missing" {
+		t.Errorf("status = %q, want missing", status)
+	}
+}
+
+func TestCond(t *testing.T) {
+	priority := func(vip bool, amount int) string {
+		return Cond(
+			CaseOf(vip, "high"),
+			CaseOf(amount > 1000, "medium"),
+			CaseOf(true, "normal"),
+		)
+	}
+	if priority(true, 0) != "high" || priority(false, 2000) != "medium" || priority(false, 10) != "normal" {
+		t.Errorf("priority = %q, %q, %q", priority(true, 0), priority(false, 2000), priority(false, 10))
+	}
+
+	if got := Cond(CaseOf(false, 1), CaseOf("", 2)); got != 0 {
+		t.Errorf("Cond(no match) = %d, want 0", got)
+	}
+	if got := Cond[int](); got != 0 {
+		t.Errorf("Cond() = %d, want 0", got)
+	}
+
+	// Mixed condition types, and CaseFunc only runs when chosen
+	called := 0
+	lazy := func() string { called++; return "lazy" }
+	got := Cond(
+		CaseOf(errors.New("boom"), "error"),
+		CaseFunc(true, lazy),
+	)
+	if got != "error" || called != 0 {
+		t.Errorf("Cond() = %q, called %d; want error without calling fn", got, called)
+	}
+	if got := Cond(CaseOf(error(nil), "error"), CaseFunc(42, lazy)); got != "lazy" || called != 1 {
+		t.Errorf("Cond() = %q, called %d; want lazy", got, called)
+	}
+}
+
+func TestCondNoAllocs(t *testing.T) {
+	score := 85
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = If(score > 90, "A", "B")
+		_ = When(score >= 90, "A").ElseIf(score >= 80, "B").ElseIfFunc(score >= 70, func() string { return "C" }).Else("F")
+		_ = Cond(CaseOf(score >= 90, "A"), CaseOf(score >= 80, "B"), CaseOf(true, "F"))
+	})
+	if allocs != 0 {
+		t.Errorf("bool conditions allocated %v times per run; want 0", allocs)
+	}
+}
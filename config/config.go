@@ -0,0 +1,93 @@
+// Package config builds configuration structs from layered sources.
+//
+// Each source (default tags, a JSON file, a .env file, environment variables,
+// command-line flags) fills a fresh copy of the config struct. Load then resolves
+// every field with ask.Coalesce semantics: the first source, in the order given,
+// that supplied a non-zero value wins. The returned Provenance records which source
+// supplied each field.
+//
+//	type AppConfig struct {
+//		Host     string `json:"host" env:"APP_HOST" flag:"host" default:"localhost"`
+//		Port     int    `json:"port" env:"APP_PORT" flag:"port" default:"8080"`
+//		Password string `json:"password" env:"APP_PASSWORD" ask:"secret"`
+//	}
+//
+//	var cfg AppConfig
+//	prov, err := config.Load(&cfg,
+//		config.Flags(flag.CommandLine),
+//		config.Env(),
+//		config.Optional(config.DotenvFile(".env")),
+//		config.Optional(config.JSONFile("config.json")),
+//		config.Defaults(),
+//	)
+//
+// Package config 按层级（命令行 > 环境变量 > .env > 配置文件 > 默认值）构建配置结构体，
+// 并记录每个字段的来源
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/crazykun/ask"
+)
+
+// Source supplies values for some fields of a config struct.
+// Load receives a pointer to a fresh zero value of the config struct and fills
+// the fields the source knows about.
+//
+// Source 配置来源，Load 接收一个指向全新零值配置结构体的指针并填充其已知字段
+type Source interface {
+	Name() string
+	Load(dst any) error
+}
+
+// Provenance maps the dotted Go path of each field ("DB.Host") to the name
+// of the source that supplied its value. Fields no source supplied are absent.
+//
+// Provenance 记录每个字段的值来自哪个来源
+type Provenance map[string]string
+
+// Load fills the struct dst points to from sources, highest precedence first.
+// Every source loads into its own fresh copy of T; for each field the first source
+// whose copy holds a non-zero value (ask.IsZero) wins, just like ask.Coalesce.
+// Nested structs are resolved field by field; pointers, slices and maps are taken
+// as a whole. Fields no source supplies keep their current value in dst.
+//
+// Errors from all sources are joined and returned together, in which case dst is
+// left unchanged.
+//
+// Load 按来源顺序（优先级从高到低）逐字段取第一个非零值填充 dst，并返回字段来源
+func Load[T any](dst *T, sources ...Source) (Provenance, error) {
+	out := reflect.ValueOf(dst).Elem()
+	if out.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config: Load requires a pointer to a struct, got %T", dst)
+	}
+
+	var errs []error
+	layers := make([]reflect.Value, len(sources))
+	for i, src := range sources {
+		layer := new(T)
+		if err := src.Load(layer); err != nil {
+			errs = append(errs, fmt.Errorf("config: %s: %w", src.Name(), err))
+		}
+		layers[i] = reflect.ValueOf(layer).Elem()
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	prov := Provenance{}
+	for _, f := range fields(out.Type()) {
+		for i, layer := range layers {
+			v := layer.FieldByIndex(f.index)
+			if !ask.IsZero(v.Interface()) {
+				out.FieldByIndex(f.index).Set(v)
+				prov[f.path] = sources[i].Name()
+				break
+			}
+		}
+	}
+	return prov, nil
+}
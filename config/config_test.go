@@ -0,0 +1,208 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crazykun/ask"
+)
+
+type testDB struct {
+	Host string `json:"host" env:"TEST_DB_HOST" default:"db.local"`
+	Port int    `json:"port" env:"TEST_DB_PORT" default:"5432"`
+}
+
+type testConfig struct {
+	Host     string        `json:"host" env:"TEST_HOST" flag:"host" default:"localhost"`
+	Port     int           `json:"port" env:"TEST_PORT" flag:"port" default:"8080"`
+	Debug    bool          `json:"debug" env:"TEST_DEBUG" flag:"debug"`
+	Timeout  time.Duration `json:"timeout" env:"TEST_TIMEOUT" default:"30s"`
+	Tags     []string      `json:"tags" env:"TEST_TAGS"`
+	Password string        `json:"password" env:"TEST_PASSWORD" ask:"secret"`
+	DB       testDB        `json:"db"`
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	jsonPath := writeFile(t, "config.json", `{"host": "file.example", "port": 9000, "tags": ["a", "b"], "db": {"host": "db.file"}}`)
+	envPath := writeFile(t, ".env", "# comment\nexport TEST_PORT=7000\nTEST_PASSWORD=\"s3cr#t\"\nTEST_DB_PORT='6543'\n")
+	t.Setenv("TEST_PORT", "6000")
+	t.Setenv("TEST_TIMEOUT", "1m")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("host", "flag-default", "")
+	fs.Int("port", 1, "")
+	fs.Bool("debug", false, "")
+	if err := fs.Parse([]string{"-debug"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg testConfig
+	prov, err := Load(&cfg,
+		Flags(fs),
+		Env(),
+		DotenvFile(envPath),
+		JSONFile(jsonPath),
+		Defaults(),
+	)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := testConfig{
+		Host:     "file.example",
+		Port:     6000,
+		Debug:    true,
+		Timeout:  time.Minute,
+		Tags:     []string{"a", "b"},
+		Password: "s3cr#t",
+		DB:       testDB{Host: "db.file", Port: 6543},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Load() cfg = %+v, want %+v", cfg, want)
+	}
+
+	wantProv := Provenance{
+		"Host":     jsonPath,
+		"Port":     "env",
+		"Debug":    "flag",
+		"Timeout":  "env",
+		"Tags":     jsonPath,
+		"Password": envPath,
+		"DB.Host":  jsonPath,
+		"DB.Port":  envPath,
+	}
+	if !reflect.DeepEqual(prov, wantProv) {
+		t.Errorf("Load() provenance = %v, want %v", prov, wantProv)
+	}
+}
+
+func TestLoadDefaultsOnly(t *testing.T) {
+	cfg := testConfig{Password: "kept"}
+	prov, err := Load(&cfg, Defaults())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Host != "localhost" || cfg.Port != 8080 || cfg.DB.Port != 5432 {
+		t.Errorf("Load() cfg = %+v", cfg)
+	}
+	if cfg.Password != "kept" {
+		t.Errorf("Password = %q, want unsupplied field kept", cfg.Password)
+	}
+	if _, ok := prov["Password"]; ok {
+		t.Errorf("provenance has Password, want absent")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.json")
+
+	var cfg testConfig
+	if _, err := Load(&cfg, JSONFile(missing)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Load(missing file) error = %v, want ErrNotExist", err)
+	}
+	if _, err := Load(&cfg, Optional(JSONFile(missing)), Defaults()); err != nil {
+		t.Errorf("Load(Optional(missing file)) error = %v", err)
+	}
+
+	t.Setenv("TEST_PORT", "abc")
+	t.Setenv("TEST_TIMEOUT", "soon")
+	cfg = testConfig{Host: "unchanged"}
+	_, err := Load(&cfg, Env(), Defaults())
+	var fe *ask.FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("Load() error = %v, want *ask.FieldError", err)
+	}
+	for _, field := range []string{"Port", "Timeout", "TEST_PORT"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("Load() error = %v, want mention of %s", err, field)
+		}
+	}
+	if cfg.Host != "unchanged" {
+		t.Errorf("Load() modified dst on error: %+v", cfg)
+	}
+
+	// Parse errors never repeat the value, which may be a secret
+	t.Setenv("TEST_PORT", "hunter2")
+	leaky := writeFile(t, ".env", "TEST_TIMEOUT=hunter2\nTEST_DB_PORT=\"hunter2\"\n")
+	_, err = Load(&cfg, Env(), DotenvFile(leaky))
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("Load(bad secret values) error = %v, want an error without the value", err)
+	}
+
+	// Empty values are unset, so the lower-precedence sources still apply
+	t.Setenv("TEST_PORT", "")
+	t.Setenv("TEST_TIMEOUT", "")
+	t.Setenv("TEST_DEBUG", "")
+	empty := writeFile(t, ".env", "TEST_DB_PORT=\n")
+	cfg = testConfig{}
+	prov, err := Load(&cfg, Env(), DotenvFile(empty), Defaults())
+	if err != nil {
+		t.Fatalf("Load(empty values) error = %v", err)
+	}
+	if cfg.Port != 8080 || cfg.Timeout != 30*time.Second || cfg.DB.Port != 5432 || prov["Port"] != "default" {
+		t.Errorf("Load(empty values) cfg = %+v, provenance %v; want defaults", cfg, prov)
+	}
+
+	bad := writeFile(t, ".env", "NOEQUALS\n")
+	if _, err := Load(&cfg, DotenvFile(bad)); err == nil {
+		t.Error("Load(bad .env) error = nil, want error")
+	}
+}
+
+func TestProvenanceTable(t *testing.T) {
+	t.Setenv("TEST_PASSWORD", "hunter2")
+
+	var cfg testConfig
+	prov, err := Load(&cfg, Env(), Defaults())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var b strings.Builder
+	if err := prov.Table(&b, &cfg); err != nil {
+		t.Fatalf("Table() error = %v", err)
+	}
+	out := b.String()
+
+	if strings.Contains(out, "hunter2") {
+		t.Errorf("Table() leaked secret:\n%s", out)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 9 {
+		t.Fatalf("Table() has %d lines, want 9:\n%s", len(lines), out)
+	}
+	for i, want := range [][]string{
+		{"FIELD", "VALUE", "SOURCE"},
+		{"Host", "localhost", "default"},
+		{"Port", "8080", "default"},
+		{"Debug", "false", "-"},
+		{"Timeout", "30s", "default"},
+		{"Tags", "[]", "-"},
+		{"Password", redacted, "env"},
+		{"DB.Host", "db.local", "default"},
+		{"DB.Port", "5432", "default"},
+	} {
+		if got := strings.Fields(lines[i]); !reflect.DeepEqual(got, want) {
+			t.Errorf("Table() line %d = %q, want %q", i, got, want)
+		}
+	}
+
+	if err := prov.Table(&b, 42); err == nil {
+		t.Error("Table(42) error = nil, want error")
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"reflect"
+
+	"github.com/crazykun/ask/internal/structs"
+)
+
+// field is one leaf of a config struct: a value that is resolved as a whole.
+type field struct {
+	path  string // dotted Go path, e.g. "DB.Host"
+	index []int  // index sequence for reflect.Value.FieldByIndex
+	sf    reflect.StructField
+}
+
+// fields lists the leaves of the struct type t in declaration order.
+// Nested structs are walked, except those structs.IsLeaf treats as single values.
+func fields(t reflect.Type) []field {
+	var out []field
+	collect(t, "", nil, &out)
+	return out
+}
+
+func collect(t reflect.Type, prefix string, index []int, out *[]field) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		idx := append(append([]int(nil), index...), i)
+		path := prefix + sf.Name
+		if sf.Type.Kind() == reflect.Struct && !structs.IsLeaf(sf.Type) {
+			collect(sf.Type, path+".", idx, out)
+			continue
+		}
+		*out = append(*out, field{path: path, index: idx, sf: sf})
+	}
+}
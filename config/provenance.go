@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"text/tabwriter"
+
+	"github.com/crazykun/ask"
+	"github.com/crazykun/ask/internal/structs"
+)
+
+// redacted replaces the value of secret fields in Table.
+const redacted = "******"
+
+// Table writes the effective configuration cfg (a struct or a pointer to one) as an
+// aligned FIELD / VALUE / SOURCE table, one row per field in declaration order.
+// Values of fields tagged `ask:"secret"` are redacted unless they are zero, and fields
+// no source supplied show "-" as their source.
+//
+//	FIELD     VALUE      SOURCE
+//	Host      0.0.0.0    flag
+//	Port      8080       default
+//	Password  ******     env
+//
+// Table 以表格形式输出最终生效的配置及其来源，ask:"secret" 字段的值会被隐藏
+func (p Provenance) Table(w io.Writer, cfg any) error {
+	rv := reflect.ValueOf(cfg)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("config: Table requires a struct or a non-nil pointer to one, got %T", cfg)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tVALUE\tSOURCE")
+	for _, f := range fields(rv.Type()) {
+		v := rv.FieldByIndex(f.index).Interface()
+		value := fmt.Sprint(v)
+		if structs.HasOption(f.sf, "secret") && !ask.IsZero(v) {
+			value = redacted
+		}
+		src, ok := p[f.path]
+		if !ok {
+			src = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", f.path, value, src)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+
+	"github.com/crazykun/ask"
+	"github.com/crazykun/ask/internal/redact"
+)
+
+// source is a Source made of a name and a load func.
+type source struct {
+	name string
+	load func(dst any) error
+}
+
+func (s source) Name() string       { return s.name }
+func (s source) Load(dst any) error { return s.load(dst) }
+
+// Defaults fills fields from their `default:"..."` tags, see ask.ApplyDefaults.
+// It is usually the last, lowest-precedence source.
+//
+// Defaults 从 default 标签读取默认值
+func Defaults() Source {
+	return source{name: "default", load: ask.ApplyDefaults}
+}
+
+// JSONFile decodes the JSON file at path into the config struct.
+// Use Optional to tolerate a missing file.
+//
+// JSONFile 从 JSON 文件读取配置
+func JSONFile(path string) Source {
+	return source{name: path, load: func(dst any) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, dst)
+	}}
+}
+
+// DotenvFile parses the .env file at path with ask.ParseDotenv and fills fields
+// by their `env:"NAME"` tags, like Env. Use Optional to tolerate a missing file.
+//
+// DotenvFile 从 .env 文件读取配置，按 env 标签匹配字段
+func DotenvFile(path string) Source {
+	return source{name: path, load: func(dst any) error {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		vars, err := ask.ParseDotenv(f)
+		if err != nil {
+			return err
+		}
+		return fillVars(dst, vars)
+	}}
+}
+
+// Env fills fields from the environment variables named by their `env:"NAME"` tags,
+// parsed like default tags. A variable set to an empty string counts as unset, as
+// in ask.EnvOr, so APP_PORT= leaves Port to the lower-precedence sources.
+//
+// Env 按 env 标签从环境变量读取配置
+func Env() Source {
+	return source{name: "env", load: func(dst any) error {
+		return fill(dst, "env", os.LookupEnv)
+	}}
+}
+
+// Flags fills fields from the flags of fs named by their `flag:"name"` tags.
+// Only flags that were set on the command line count, so a flag's own default
+// never hides a lower-precedence source. fs must already be parsed.
+//
+// Flags 按 flag 标签从命令行参数读取配置，仅使用显式设置过的参数
+func Flags(fs *flag.FlagSet) Source {
+	return source{name: "flag", load: func(dst any) error {
+		set := map[string]string{}
+		fs.Visit(func(f *flag.Flag) {
+			set[f.Name] = f.Value.String()
+		})
+		return fill(dst, "flag", func(name string) (string, bool) {
+			v, ok := set[name]
+			return v, ok
+		})
+	}}
+}
+
+// Optional wraps src so that a missing file (fs.ErrNotExist) is not an error;
+// the source then simply supplies nothing.
+//
+// Optional 包装来源，文件不存在时不报错
+func Optional(src Source) Source {
+	return source{name: src.Name(), load: func(dst any) error {
+		if err := src.Load(dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}}
+}
+
+// fill sets every leaf field of the struct dst points to whose tag names a key
+// lookup knows. Empty values count as unset: they could only ever yield a zero
+// value, which Load skips anyway. Parse errors are returned per field, joined, and
+// never quote the value, which may be a secret.
+func fill(dst any, tag string, lookup func(string) (string, bool)) error {
+	rv := reflect.ValueOf(dst).Elem()
+	var errs []error
+	for _, f := range fields(rv.Type()) {
+		key := f.sf.Tag.Get(tag)
+		if key == "" || key == "-" {
+			continue
+		}
+		s, ok := lookup(key)
+		if !ok || s == "" {
+			continue
+		}
+		if err := ask.ParseInto(rv.FieldByIndex(f.index).Addr().Interface(), s); err != nil {
+			err = redact.Value(err, s)
+			errs = append(errs, &ask.FieldError{Field: f.path, Err: fmt.Errorf("%s %q: %w", tag, key, err)})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// fillVars fills fields by their env tags from vars, as read from a .env file.
+func fillVars(dst any, vars map[string]string) error {
+	return fill(dst, "env", ask.LookupMap(vars))
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/crazykun/ask"
+)
+
+// Change describes one field whose value differs between two snapshots.
+//
+// Change 描述两个快照之间某个字段的变化
+type Change struct {
+	Field string // dotted Go path, as in Provenance
+	Old   any
+	New   any
+}
+
+// Watcher keeps a configuration snapshot in sync with a JSON or .env file.
+// Every reload builds a fresh T from the file and its default tags, checks it with
+// ask.Require and, if valid, publishes it atomically. Snapshots are shared between
+// goroutines and must be treated as read-only.
+//
+// Watcher 轮询配置文件，每次变更后重新应用默认值并校验必填字段，通过 atomic.Pointer 发布不可变快照
+type Watcher[T any] struct {
+	path     string
+	interval time.Duration
+	snap     atomic.Pointer[T]
+
+	reloadMu sync.Mutex // serializes reloads
+	last     []byte     // file content of the last reload attempt
+	readErr  string     // message of the last read error, empty once the file is readable
+
+	mu      sync.Mutex // guards subs, nextID and onError
+	subs    map[int]func(old, new *T, changes []Change)
+	nextID  int
+	onError func(error)
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// Watch loads the file at path and starts polling it every interval. Files named
+// *.json are decoded with ask.DecodeJSONWithDefaults: keys missing from the file (or
+// null) get their `default:"..."` tags, while explicit values, zeros included, are
+// kept. Anything else is read as a .env file and matched by `env:"NAME"` tags; there
+// an empty value cannot be told from a missing one, so fields left zero get defaults.
+//
+// The initial load must succeed, or Watch returns its error. Later reloads only
+// happen when the file content changes; an invalid reload (unreadable file, decode
+// error, bad default, missing required field) keeps the previous snapshot and is
+// reported to the OnError callback once, not again until the error changes.
+// Call Stop to end polling.
+//
+//	w, err := config.Watch[AppConfig]("config.json", 5*time.Second)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer w.Stop()
+//	w.OnError(func(err error) { log.Println("config:", err) })
+//	w.Subscribe(func(old, new *AppConfig, changes []config.Change) { ... })
+//
+//	cfg := w.Current()
+//
+// Watch 加载配置文件并按 interval 轮询，首次加载失败时返回错误
+func Watch[T any](path string, interval time.Duration) (*Watcher[T], error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("config: Watch requires a positive interval, got %v", interval)
+	}
+	w := &Watcher[T]{
+		path:     path,
+		interval: interval,
+		subs:     map[int]func(old, new *T, changes []Change){},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	cfg, err := w.build(data)
+	if err != nil {
+		return nil, err
+	}
+	w.last = data
+	w.snap.Store(cfg)
+
+	go w.run()
+	return w, nil
+}
+
+// Current returns the latest valid snapshot. It never returns nil.
+//
+// Current 返回最新的有效配置快照
+func (w *Watcher[T]) Current() *T {
+	return w.snap.Load()
+}
+
+// Subscribe registers fn to be called after every reload that changes at least one
+// field, with the previous and the new snapshot and the changed fields in declaration
+// order. Callbacks run one at a time on the watcher's goroutine (or the caller of
+// Reload). The returned func removes the subscription.
+//
+// Subscribe 注册配置变更回调，返回取消订阅的函数
+func (w *Watcher[T]) Subscribe(fn func(old, new *T, changes []Change)) (cancel func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	id := w.nextID
+	w.nextID++
+	w.subs[id] = fn
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subs, id)
+	}
+}
+
+// OnError sets the func that receives the errors of failed reloads.
+// Without one, such errors are dropped; the previous snapshot stays in place either way.
+//
+// OnError 设置重新加载失败时的错误回调
+func (w *Watcher[T]) OnError(fn func(error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onError = fn
+}
+
+// Reload reads the file now, even if its content did not change, and publishes the
+// result as Watch does. It returns the reload error, which is also passed to OnError.
+//
+// Reload 立即重新加载配置文件
+func (w *Watcher[T]) Reload() error {
+	return w.reload(true)
+}
+
+// Stop ends polling and waits for the watcher's goroutine to exit. It is safe to call
+// more than once. Current keeps returning the last snapshot.
+//
+// Stop 停止轮询
+func (w *Watcher[T]) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+	<-w.done
+}
+
+func (w *Watcher[T]) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			_ = w.reload(false)
+		}
+	}
+}
+
+func (w *Watcher[T]) reload(force bool) error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		err = fmt.Errorf("config: %w", err)
+		// Like invalid content, an unreadable file is reported once, not on every tick
+		if !force && err.Error() == w.readErr {
+			return err
+		}
+		w.readErr = err.Error()
+		return w.fail(err)
+	}
+	w.readErr = ""
+	if !force && bytes.Equal(data, w.last) {
+		return nil
+	}
+	// Remember the content even if it turns out invalid, so it is reported only once
+	w.last = data
+
+	next, err := w.build(data)
+	if err != nil {
+		return w.fail(err)
+	}
+	old := w.snap.Swap(next)
+
+	changes := diff(old, next)
+	if len(changes) == 0 {
+		return nil
+	}
+	w.mu.Lock()
+	subs := make([]func(old, new *T, changes []Change), 0, len(w.subs))
+	for id := 0; id < w.nextID; id++ {
+		if fn, ok := w.subs[id]; ok {
+			subs = append(subs, fn)
+		}
+	}
+	w.mu.Unlock()
+	for _, fn := range subs {
+		fn(old, next, changes)
+	}
+	return nil
+}
+
+// build decodes data into a fresh T, applies defaults and checks required fields.
+func (w *Watcher[T]) build(data []byte) (*T, error) {
+	cfg := new(T)
+	if strings.EqualFold(filepath.Ext(w.path), ".json") {
+		if err := ask.DecodeJSONWithDefaults(bytes.NewReader(data), cfg); err != nil {
+			return nil, fmt.Errorf("config: %s: %w", w.path, err)
+		}
+	} else {
+		file := source{name: w.path, load: func(dst any) error {
+			vars, err := ask.ParseDotenv(bytes.NewReader(data))
+			if err != nil {
+				return err
+			}
+			return fillVars(dst, vars)
+		}}
+		if _, err := Load(cfg, file, Defaults()); err != nil {
+			return nil, err
+		}
+	}
+	if err := ask.Require(cfg); err != nil {
+		return nil, fmt.Errorf("config: %s: %w", w.path, err)
+	}
+	return cfg, nil
+}
+
+func (w *Watcher[T]) fail(err error) error {
+	w.mu.Lock()
+	fn := w.onError
+	w.mu.Unlock()
+	if fn != nil {
+		fn(err)
+	}
+	return err
+}
+
+// diff lists the leaf fields whose values differ between old and new.
+func diff[T any](old, new *T) []Change {
+	ov, nv := reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem()
+	var changes []Change
+	for _, f := range fields(nv.Type()) {
+		o, n := ov.FieldByIndex(f.index).Interface(), nv.FieldByIndex(f.index).Interface()
+		if !reflect.DeepEqual(o, n) {
+			changes = append(changes, Change{Field: f.path, Old: o, New: n})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/crazykun/ask"
+)
+
+type watchConfig struct {
+	Name    string        `json:"name" env:"NAME" ask:"required"`
+	Workers int           `json:"workers" env:"WORKERS" default:"4"`
+	Timeout time.Duration `json:"timeout" env:"TIMEOUT" default:"30s"`
+	DB      testDB        `json:"db"`
+}
+
+func TestWatchReload(t *testing.T) {
+	path := writeFile(t, "config.json", `{"name": "app"}`)
+
+	w, err := Watch[watchConfig](path, time.Hour)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Stop()
+
+	first := w.Current()
+	want := watchConfig{Name: "app", Workers: 4, Timeout: 30 * time.Second, DB: testDB{Host: "db.local", Port: 5432}}
+	if !reflect.DeepEqual(*first, want) {
+		t.Fatalf("Current() = %+v, want %+v", *first, want)
+	}
+
+	var calls int
+	var gotOld, gotNew *watchConfig
+	var gotChanges []Change
+	cancel := w.Subscribe(func(old, new *watchConfig, changes []Change) {
+		calls++
+		gotOld, gotNew, gotChanges = old, new, changes
+	})
+	var errs []error
+	w.OnError(func(err error) { errs = append(errs, err) })
+
+	// A valid change publishes a new snapshot with a field-level diff
+	if err := os.WriteFile(path, []byte(`{"name": "app", "workers": 8, "db": {"port": 6543}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if calls != 1 || gotOld != first || gotNew != w.Current() {
+		t.Fatalf("subscriber calls = %d, old %p new %p", calls, gotOld, gotNew)
+	}
+	wantChanges := []Change{
+		{Field: "Workers", Old: 4, New: 8},
+		{Field: "DB.Port", Old: 5432, New: 6543},
+	}
+	if !reflect.DeepEqual(gotChanges, wantChanges) {
+		t.Errorf("changes = %+v, want %+v", gotChanges, wantChanges)
+	}
+	if first.Workers != 4 {
+		t.Errorf("old snapshot modified: %+v", *first)
+	}
+
+	// Reloading identical content notifies nobody
+	if err := w.Reload(); err != nil || calls != 1 {
+		t.Errorf("Reload(unchanged) = %v, calls = %d; want nil, 1", err, calls)
+	}
+
+	// Invalid reloads keep the previous snapshot and report the error
+	current := w.Current()
+	for _, content := range []string{`{"name": `, `{"workers": 2}`, `{"name": "app", "timeout": "soon"}`} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Reload(); err == nil {
+			t.Errorf("Reload(%s) error = nil, want error", content)
+		}
+		if w.Current() != current {
+			t.Errorf("Reload(%s) replaced the snapshot", content)
+		}
+	}
+	if len(errs) != 3 || !errors.Is(errs[1], ask.ErrRequired) {
+		t.Errorf("OnError got %v, want 3 errors with ErrRequired second", errs)
+	}
+
+	cancel()
+	if err := os.WriteFile(path, []byte(`{"name": "other"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Reload(); err != nil || calls != 1 || w.Current().Name != "other" {
+		t.Errorf("Reload() after cancel = %v, calls = %d, name = %q", err, calls, w.Current().Name)
+	}
+}
+
+func TestWatchExplicitZero(t *testing.T) {
+	path := writeFile(t, "config.json", `{"name": "app", "workers": 0, "db": {"port": 0}}`)
+
+	w, err := Watch[watchConfig](path, time.Hour)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Stop()
+
+	// Explicit zeros in a JSON file are kept; only missing keys get defaults
+	want := watchConfig{Name: "app", Workers: 0, Timeout: 30 * time.Second, DB: testDB{Host: "db.local", Port: 0}}
+	if got := w.Current(); !reflect.DeepEqual(*got, want) {
+		t.Fatalf("Current() = %+v, want %+v", *got, want)
+	}
+
+	var gotChanges []Change
+	w.Subscribe(func(_, _ *watchConfig, changes []Change) { gotChanges = changes })
+	if err := os.WriteFile(path, []byte(`{"name": "app", "db": {"port": 0}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if !reflect.DeepEqual(gotChanges, []Change{{Field: "Workers", Old: 0, New: 4}}) {
+		t.Errorf("changes after removing workers = %+v", gotChanges)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"name": "app", "workers": 0, "db": {"port": 0}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Reload(); err != nil || w.Current().Workers != 0 {
+		t.Errorf("Reload(workers 0) = %v, Workers = %d; want explicit 0 kept", err, w.Current().Workers)
+	}
+}
+
+func TestWatchPolling(t *testing.T) {
+	path := writeFile(t, "app.env", "NAME=app\n")
+
+	w, err := Watch[watchConfig](path, 5*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Stop()
+
+	changed := make(chan []Change, 1)
+	w.Subscribe(func(_, _ *watchConfig, changes []Change) { changed <- changes })
+
+	if err := os.WriteFile(path, []byte("NAME=app\nWORKERS=16\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case changes := <-changed:
+		if len(changes) != 1 || changes[0].Field != "Workers" || changes[0].New != 16 {
+			t.Errorf("changes = %+v", changes)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change notification after file update")
+	}
+	if got := w.Current().Workers; got != 16 {
+		t.Errorf("Workers = %d, want 16", got)
+	}
+
+	w.Stop()
+	w.Stop()
+}
+
+func TestWatchErrors(t *testing.T) {
+	if _, err := Watch[watchConfig](writeFile(t, "c.json", `{}`), time.Hour); !errors.Is(err, ask.ErrRequired) {
+		t.Errorf("Watch(missing required) error = %v, want ErrRequired", err)
+	}
+	if _, err := Watch[watchConfig](writeFile(t, "c.json", `{"name": "x"}`), 0); err == nil {
+		t.Error("Watch(interval 0) error = nil, want error")
+	}
+
+	path := writeFile(t, "c.json", `{"name": "x"}`)
+	w, err := Watch[watchConfig](path, time.Hour)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Stop()
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Reload(); !errors.Is(err, os.ErrNotExist) || w.Current().Name != "x" {
+		t.Errorf("Reload(removed file) = %v, name = %q", err, w.Current().Name)
+	}
+}
+
+func TestWatchReadErrorReportedOnce(t *testing.T) {
+	path := writeFile(t, "c.json", `{"name": "x"}`)
+	w, err := Watch[watchConfig](path, time.Hour)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Stop()
+	var reported []error
+	w.OnError(func(err error) { reported = append(reported, err) })
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := w.reload(false); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("reload() error = %v, want ErrNotExist", err)
+		}
+	}
+	if len(reported) != 1 {
+		t.Errorf("OnError called %d times for the same read error, want 1", len(reported))
+	}
+
+	// Once the file is readable again, a later read error is new and reported again
+	if err := os.WriteFile(path, []byte(`{"name": "y"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.reload(false); err != nil || w.Current().Name != "y" {
+		t.Errorf("reload() = %v, name = %q", err, w.Current().Name)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	_ = w.reload(false)
+	if len(reported) != 2 {
+		t.Errorf("OnError called %d times, want 2 after the file came back and went away", len(reported))
+	}
+}
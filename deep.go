@@ -0,0 +1,126 @@
+package ask
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// IsDeepZero checks if a value is zero by applying the package's own rules recursively.
+// Unlike IsZero, which compares a struct or array as a whole, IsDeepZero looks at every
+// field, array element and pointee:
+//   - nil pointers, interfaces, channels and funcs are zero; non-nil pointers and
+//     interfaces are zero when what they point to is deep zero
+//   - slices and maps are zero when they are nil or empty, just like IsZero
+//   - arrays and structs are zero when every element or field is deep zero
+//   - funcs registered with RegisterZeroFunc and IsZero() bool methods decide for themselves
+//
+// Pointer cycles are detected: a pointer that is already being checked counts as zero,
+// so the result is decided by the rest of the value. A config struct made only of
+// empty containers and pointers to zero structs is therefore deep zero.
+//
+// IsDeepZero 递归判断零值：逐个检查结构体字段、数组元素和指针指向的值，
+// 空切片、空映射视为零值，能检测指针循环引用
+func IsDeepZero(v any) bool {
+	return IsDeepZeroDepth(v, 0)
+}
+
+// IsDeepZeroDepth is IsDeepZero with a depth limit.
+// Values nested deeper than maxDepth levels are checked with IsZero instead of
+// being descended into. A maxDepth of 0 or less means no limit.
+//
+// IsDeepZeroDepth 带深度限制的 IsDeepZero，超过 maxDepth 的层级使用 IsZero 判断，
+// maxDepth <= 0 表示不限制深度
+func IsDeepZeroDepth(v any, maxDepth int) bool {
+	if v == nil {
+		return true
+	}
+	d := deepZero{maxDepth: maxDepth}
+	return d.check(reflect.ValueOf(v), 0)
+}
+
+// visit identifies a pointee by address and type, since a struct and its first field share an address.
+type visit struct {
+	ptr unsafe.Pointer
+	typ reflect.Type
+}
+
+type deepZero struct {
+	maxDepth int
+	visited  map[visit]bool
+}
+
+func (d *deepZero) check(rv reflect.Value, depth int) bool {
+	if !rv.IsValid() {
+		return true
+	}
+	if d.maxDepth > 0 && depth > d.maxDepth {
+		if rv.CanInterface() {
+			return IsZero(rv.Interface())
+		}
+		return rv.IsZero()
+	}
+
+	// Registered funcs and types with their own IsZero method decide for themselves
+	// 注册的判断函数及自定义 IsZero 方法优先
+	if k := rv.Kind(); k != reflect.Interface && rv.CanInterface() {
+		v := rv.Interface()
+		if fn := zeroFuncs.lookup(v); fn != nil {
+			return fn(v)
+		}
+		if k != reflect.Ptr {
+			if method := zeroMethods.get(v); method != nil {
+				return method(v)
+			}
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return true
+		}
+		key := visit{rv.UnsafePointer(), rv.Type()}
+		if d.visited[key] {
+			return true
+		}
+		if d.visited == nil {
+			d.visited = make(map[visit]bool)
+		}
+		d.visited[key] = true
+		return d.check(rv.Elem(), depth+1)
+	case reflect.Interface:
+		if rv.IsNil() {
+			return true
+		}
+		return d.check(rv.Elem(), depth+1)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return rv.IsNil()
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	case reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if !d.check(rv.Index(i), depth+1) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if !d.check(rv.Field(i), depth+1) {
+				return false
+			}
+		}
+		return true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	case reflect.Complex64, reflect.Complex128:
+		return rv.Complex() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+// zeroMethods caches the IsZero method checker per type; types without one map to nil.
+var zeroMethods = &checkerCache{compile: func(t reflect.Type) checker {
+	return methodChecker(t, zeroerType, func(z zeroer) bool { return z.IsZero() })
+}}
@@ -0,0 +1,81 @@
+package ask
+
+import (
+	"testing"
+	"time"
+)
+
+type deepServer struct {
+	Hosts   []string
+	Labels  map[string]string
+	Timeout time.Time
+}
+
+type deepConfig struct {
+	Name    string
+	Server  deepServer
+	Backup  *deepServer
+	Plugins [2][]string
+	Extra   any
+}
+
+type node struct {
+	Value int
+	Next  *node
+}
+
+func TestIsDeepZero(t *testing.T) {
+	loopZero := &node{}
+	loopZero.Next = loopZero
+	loopSet := &node{}
+	loopSet.Next = &node{Value: 1, Next: loopSet}
+
+	tests := []struct {
+		name   string
+		value  any
+		expect bool
+	}{
+		{"nil", nil, true},
+		{"zero config", deepConfig{}, true},
+		{"empty containers", deepConfig{Server: deepServer{Hosts: []string{}, Labels: map[string]string{}}}, true},
+		{"pointer to empty struct", deepConfig{Backup: &deepServer{Hosts: []string{}}}, true},
+		{"time with location only", deepConfig{Server: deepServer{Timeout: time.Time{}.In(time.UTC)}}, true},
+		{"array of empty slices", deepConfig{Plugins: [2][]string{{}, {}}}, true},
+		{"interface holding zero", deepConfig{Extra: deepServer{}}, true},
+		{"set name", deepConfig{Name: "app"}, false},
+		{"nested host", deepConfig{Server: deepServer{Hosts: []string{"a"}}}, false},
+		{"pointer to set struct", deepConfig{Backup: &deepServer{Labels: map[string]string{"a": "b"}}}, false},
+		{"interface holding value", deepConfig{Extra: 1}, false},
+		{"self cycle", loopZero, true},
+		{"cycle with value", loopSet, false},
+		{"negative zero float", struct{ F float64 }{F: -0.0}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDeepZero(tt.value); got != tt.expect {
+				t.Errorf("IsDeepZero(%+v) = %v; want %v", tt.value, got, tt.expect)
+			}
+		})
+	}
+
+	if IsZero(deepConfig{Server: deepServer{Hosts: []string{}}}) {
+		t.Errorf("IsZero is shallow and should report a struct with a non-nil slice as non-zero")
+	}
+}
+
+func TestIsDeepZeroDepth(t *testing.T) {
+	v := deepConfig{Backup: &deepServer{Hosts: []string{}}}
+
+	// Backup is at depth 1, its pointee at depth 2 and Hosts at depth 3.
+	if !IsDeepZeroDepth(v, 3) {
+		t.Errorf("IsDeepZeroDepth(v, 3) = false; want true")
+	}
+	// With a limit of 1 the non-nil Backup pointer is checked with IsZero.
+	if IsDeepZeroDepth(v, 1) {
+		t.Errorf("IsDeepZeroDepth(v, 1) = true; want false")
+	}
+	if !IsDeepZeroDepth(v, 0) {
+		t.Errorf("IsDeepZeroDepth(v, 0) = false; want true (no limit)")
+	}
+}
@@ -0,0 +1,153 @@
+package ask
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/crazykun/ask/internal/structs"
+)
+
+// FieldError records a problem with one struct field, identified by its dotted path.
+// Functions that check many fields return their FieldErrors combined with errors.Join,
+// so errors.As can be used to inspect each of them.
+//
+// FieldError 记录某个结构体字段的错误，Field 为点分隔的字段路径
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return "ask: field " + e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// defaultsSetter is implemented by types that compute their own defaults.
+type defaultsSetter interface {
+	SetDefaults()
+}
+
+var defaultsSetterType = reflect.TypeOf((*defaultsSetter)(nil)).Elem()
+
+// ApplyDefaults fills every zero field of the struct ptr points to from its
+// `default:"..."` tag. Zeroness follows IsZero, so empty slices and maps are filled too.
+//
+// Tag values are parsed according to the field type: strings, bools, ints and uints
+// (decimal unless prefixed with 0x, 0o or 0b, so "010" is 10), floats,
+// time.Duration ("30s"), time.Time (RFC 3339), encoding.TextUnmarshaler
+// implementations, slices as comma-separated elements ("a,b,c") and maps as
+// comma-separated key=value pairs ("a=1,b=2").
+//
+// Nested structs are filled recursively, except those set as a single value such
+// as time.Time or encoding.TextUnmarshaler implementations. A nil pointer to a
+// struct is allocated when that struct has defaults of its own. After its fields
+// are filled, every struct with a SetDefaults() method (on the pointer receiver)
+// has it called, innermost first, so it can derive values from the tag defaults.
+//
+// Fields whose tag cannot be parsed are left unchanged; their errors are returned
+// as *FieldError values combined with errors.Join.
+//
+//	type Config struct {
+//		Host    string        `default:"localhost"`
+//		Port    int           `default:"8080"`
+//		Timeout time.Duration `default:"30s"`
+//	}
+//
+// ApplyDefaults 根据 default 标签为结构体的零值字段设置默认值，
+// 支持嵌套结构体和 SetDefaults() 方法，解析错误按字段汇总返回
+func ApplyDefaults(ptr any) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ask: ApplyDefaults requires a non-nil pointer to a struct, got %T", ptr)
+	}
+
+	var errs []error
+	applyDefaults(rv.Elem(), "", map[reflect.Type]bool{}, &errs)
+	return errors.Join(errs...)
+}
+
+// applyDefaults fills rv; active holds the struct types being filled on the current
+// path, so pointers back to one of them (linked lists, cycles) are not followed.
+func applyDefaults(rv reflect.Value, prefix string, active map[reflect.Type]bool, errs *[]error) {
+	t := rv.Type()
+	active[t] = true
+	defer delete(active, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		applyFieldDefaults(f, rv.Field(i), prefix+f.Name, active, errs)
+	}
+
+	callSetDefaults(rv)
+}
+
+// applyFieldDefaults fills the field fv from its default tag and descends into it
+// when it is a struct or a pointer to one.
+func applyFieldDefaults(f reflect.StructField, fv reflect.Value, path string, active map[reflect.Type]bool, errs *[]error) {
+	if def, ok := f.Tag.Lookup("default"); ok && def != "" && IsZero(fv.Interface()) {
+		if err := setString(fv, def); err != nil {
+			*errs = append(*errs, &FieldError{Field: path, Err: err})
+		}
+	}
+
+	switch {
+	case fv.Kind() == reflect.Struct && !structs.IsLeaf(fv.Type()):
+		applyDefaults(fv, path+".", active, errs)
+	case fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct && !structs.IsLeaf(fv.Type().Elem()):
+		elem := fv.Type().Elem()
+		if active[elem] {
+			return
+		}
+		if fv.IsNil() {
+			if !hasDefaults(elem, map[reflect.Type]bool{}) {
+				return
+			}
+			fv.Set(reflect.New(elem))
+		}
+		applyDefaults(fv.Elem(), path+".", active, errs)
+	}
+}
+
+// callSetDefaults calls the SetDefaults method of rv, if it has one.
+func callSetDefaults(rv reflect.Value) {
+	if rv.CanAddr() && rv.Addr().Type().Implements(defaultsSetterType) {
+		rv.Addr().Interface().(defaultsSetter).SetDefaults()
+	}
+}
+
+// hasDefaults reports whether a struct type has any default tag or SetDefaults method,
+// directly or in nested structs.
+func hasDefaults(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	if reflect.PointerTo(t).Implements(defaultsSetterType) {
+		return true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if def, ok := f.Tag.Lookup("default"); ok && def != "" {
+			return true
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && !structs.IsLeaf(ft) && hasDefaults(ft, seen) {
+			return true
+		}
+	}
+	return false
+}
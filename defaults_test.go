@@ -0,0 +1,190 @@
+package ask
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type defaultsDB struct {
+	DSN     string `default:"postgres://localhost/app"`
+	MaxConn int    `default:"10"`
+}
+
+type defaultsCache struct {
+	TTL time.Duration `default:"5m"`
+}
+
+type defaultsConfig struct {
+	Host     string            `default:"localhost"`
+	Port     int               `default:"8080"`
+	Debug    bool              `default:"true"`
+	Ratio    float64           `default:"0.75"`
+	Retries  uint8             `default:"3"`
+	Timeout  time.Duration     `default:"30s"`
+	Since    time.Time         `default:"2024-01-02T03:04:05Z"`
+	Hosts    []string          `default:"a, b,c"`
+	Ports    []int             `default:"80,443"`
+	Labels   map[string]string `default:"env=dev, team=core"`
+	Weights  map[string]int    `default:"a=1,b=2"`
+	Addr     netip.Addr        `default:"127.0.0.1"`
+	MaxBytes *int64            `default:"1024"`
+	NoTag    string
+	DB       defaultsDB
+	Cache    *defaultsCache
+	Optional *struct{ Name string }
+	private  string `default:"ignored"`
+}
+
+func (c *defaultsConfig) SetDefaults() {
+	if c.NoTag == "" {
+		c.NoTag = c.Host + "-derived"
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	var cfg defaultsConfig
+	if err := ApplyDefaults(&cfg); err != nil {
+		t.Fatalf("ApplyDefaults() error = %v", err)
+	}
+
+	maxBytes := int64(1024)
+	want := defaultsConfig{
+		Host:     "localhost",
+		Port:     8080,
+		Debug:    true,
+		Ratio:    0.75,
+		Retries:  3,
+		Timeout:  30 * time.Second,
+		Since:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Hosts:    []string{"a", "b", "c"},
+		Ports:    []int{80, 443},
+		Labels:   map[string]string{"env": "dev", "team": "core"},
+		Weights:  map[string]int{"a": 1, "b": 2},
+		Addr:     netip.MustParseAddr("127.0.0.1"),
+		MaxBytes: &maxBytes,
+		NoTag:    "localhost-derived",
+		DB:       defaultsDB{DSN: "postgres://localhost/app", MaxConn: 10},
+		Cache:    &defaultsCache{TTL: 5 * time.Minute},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("ApplyDefaults =\n%+v\nwant\n%+v", cfg, want)
+	}
+}
+
+func TestApplyDefaultsKeepsSetFields(t *testing.T) {
+	cfg := defaultsConfig{Host: "example.com", Port: 9000, Hosts: []string{"x"}, DB: defaultsDB{MaxConn: 1}}
+	if err := ApplyDefaults(&cfg); err != nil {
+		t.Fatalf("ApplyDefaults() error = %v", err)
+	}
+	if cfg.Host != "example.com" || cfg.Port != 9000 || len(cfg.Hosts) != 1 || cfg.DB.MaxConn != 1 {
+		t.Errorf("ApplyDefaults overwrote set fields: %+v", cfg)
+	}
+	if cfg.NoTag != "example.com-derived" {
+		t.Errorf("SetDefaults did not see tag defaults: NoTag = %q", cfg.NoTag)
+	}
+	if cfg.DB.DSN != "postgres://localhost/app" {
+		t.Errorf("nested default not applied: %+v", cfg.DB)
+	}
+}
+
+func TestApplyDefaultsErrors(t *testing.T) {
+	type bad struct {
+		Port    int           `default:"eighty"`
+		Timeout time.Duration `default:"soon"`
+		Host    string        `default:"ok"`
+		Nested  struct {
+			Ratio float64 `default:"x"`
+		}
+	}
+
+	var v bad
+	err := ApplyDefaults(&v)
+	if err == nil {
+		t.Fatal("ApplyDefaults() error = nil; want parse errors")
+	}
+	if v.Host != "ok" {
+		t.Errorf("valid field not filled alongside errors: Host = %q", v.Host)
+	}
+
+	var fields []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var fe *FieldError
+		if !errors.As(e, &fe) {
+			t.Fatalf("error %v is not a *FieldError", e)
+		}
+		fields = append(fields, fe.Field)
+	}
+	if want := []string{"Port", "Timeout", "Nested.Ratio"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("failed fields = %v; want %v", fields, want)
+	}
+}
+
+func TestApplyDefaultsInvalidTarget(t *testing.T) {
+	for _, v := range []any{nil, defaultsConfig{}, (*defaultsConfig)(nil), new(int)} {
+		if err := ApplyDefaults(v); err == nil {
+			t.Errorf("ApplyDefaults(%T) error = nil; want error", v)
+		}
+	}
+}
+
+type defaultsNode struct {
+	Name string `default:"node"`
+	Next *defaultsNode
+}
+
+func TestApplyDefaultsSelfReference(t *testing.T) {
+	n := &defaultsNode{}
+	n.Next = n
+	if err := ApplyDefaults(n); err != nil {
+		t.Fatalf("ApplyDefaults() error = %v", err)
+	}
+	if n.Name != "node" {
+		t.Errorf("Name = %q; want node", n.Name)
+	}
+}
+
+// defaultsAddr is parsed from "host:port" as a whole; its own tags are never read.
+type defaultsAddr struct {
+	Host string
+	Port string `default:"80"`
+}
+
+func (a *defaultsAddr) UnmarshalText(b []byte) error {
+	a.Host, a.Port, _ = strings.Cut(string(b), ":")
+	return nil
+}
+
+func TestApplyDefaultsTextLeaf(t *testing.T) {
+	var cfg struct {
+		Listen defaultsAddr `default:"0.0.0.0:8080"`
+		Peer   defaultsAddr
+		Proxy  *defaultsAddr
+	}
+	if err := ApplyDefaults(&cfg); err != nil {
+		t.Fatalf("ApplyDefaults() error = %v", err)
+	}
+	if cfg.Listen != (defaultsAddr{Host: "0.0.0.0", Port: "8080"}) {
+		t.Errorf("Listen = %+v; want the parsed tag", cfg.Listen)
+	}
+	if cfg.Peer != (defaultsAddr{}) || cfg.Proxy != nil {
+		t.Errorf("Peer = %+v, Proxy = %v; want both left unset", cfg.Peer, cfg.Proxy)
+	}
+}
+
+func TestApplyDefaultsDecimal(t *testing.T) {
+	var cfg struct {
+		Mode  int    `default:"010"`
+		Port  uint16 `default:"08080"`
+		Flags int    `default:"0x10"`
+	}
+	if err := ApplyDefaults(&cfg); err != nil {
+		t.Fatalf("ApplyDefaults() error = %v", err)
+	}
+	if cfg.Mode != 10 || cfg.Port != 8080 || cfg.Flags != 16 {
+		t.Errorf("cfg = %+v; want leading zeros read as decimal", cfg)
+	}
+}
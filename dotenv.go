@@ -0,0 +1,225 @@
+package ask
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ParseDotenv reads a .env file and returns its variables without touching the process
+// environment. The format follows the common dotenv conventions:
+//
+//	# full-line comments and blank lines are ignored
+//	export HOST=localhost          # an "export " prefix is allowed; so are inline comments
+//	NAME = demo app                # unquoted values are trimmed
+//	GREETING="hello\tworld\n"      # double quotes: \n \r \t \" \\ \$ escapes, ${VAR} expanded
+//	PATTERN='^\d+$ ${LITERAL}'     # single quotes: taken literally
+//	CERT="-----BEGIN CERT-----
+//	...
+//	-----END CERT-----"            # quoted values may span lines
+//	URL=http://${HOST}:${PORT:-8080}
+//
+// References in unquoted and double-quoted values are expanded with Expand, so all of its
+// operators work; they resolve against the variables defined earlier in the file, then
+// against the process environment. An inline comment must be preceded by a space.
+//
+// ParseDotenv 解析 .env 文件并返回变量表，不修改进程环境变量；
+// 支持引号、转义、export 前缀、注释、多行值及 ${VAR} 引用
+func ParseDotenv(r io.Reader) (map[string]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := map[string]string{}
+	lookup := LookupMap(vars).Or(os.LookupEnv)
+	p := &dotenvParser{src: string(data), line: 1}
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return vars, nil
+		}
+		line := p.line
+		key, val, expand, err := p.entry()
+		if err != nil {
+			return nil, fmt.Errorf("ask: dotenv line %d: %w", line, err)
+		}
+		if expand {
+			if val, err = Expand(val, lookup); err != nil {
+				return nil, fmt.Errorf("ask: dotenv line %d: %w", line, err)
+			}
+		}
+		vars[key] = val
+	}
+}
+
+// dotenvParser scans a .env document, tracking the line number for errors.
+type dotenvParser struct {
+	src  string
+	pos  int
+	line int
+}
+
+func (p *dotenvParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *dotenvParser) next() byte {
+	c := p.src[p.pos]
+	p.pos++
+	if c == '\n' {
+		p.line++
+	}
+	return c
+}
+
+// skipSpaces skips spaces and tabs on the current line.
+func (p *dotenvParser) skipSpaces() {
+	for !p.eof() && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// skipBlank skips whitespace, blank lines and comment lines.
+func (p *dotenvParser) skipBlank() {
+	for !p.eof() {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.next()
+		case '#':
+			p.skipLine()
+		default:
+			return
+		}
+	}
+}
+
+// skipLine skips to the start of the next line.
+func (p *dotenvParser) skipLine() {
+	for !p.eof() && p.next() != '\n' {
+	}
+}
+
+// endLine checks that only spaces or a comment follow a quoted value on its line.
+func (p *dotenvParser) endLine() error {
+	p.skipSpaces()
+	if p.eof() {
+		return nil
+	}
+	switch p.src[p.pos] {
+	case '#', '\n', '\r':
+		p.skipLine()
+		return nil
+	}
+	return fmt.Errorf("unexpected %q after closing quote", p.src[p.pos])
+}
+
+// entry parses one KEY=VALUE entry and reports whether the value needs expansion.
+func (p *dotenvParser) entry() (key, val string, expand bool, err error) {
+	if strings.HasPrefix(p.src[p.pos:], "export ") || strings.HasPrefix(p.src[p.pos:], "export\t") {
+		p.pos += len("export")
+		p.skipSpaces()
+	}
+
+	start := p.pos
+	for !p.eof() && isDotenvKeyChar(p.src[p.pos]) {
+		p.pos++
+	}
+	key = p.src[start:p.pos]
+	if key == "" {
+		return "", "", false, fmt.Errorf("invalid variable name at %q", p.rest())
+	}
+
+	p.skipSpaces()
+	if p.eof() || p.src[p.pos] != '=' {
+		return "", "", false, fmt.Errorf("missing '=' after %s", key)
+	}
+	p.pos++
+	p.skipSpaces()
+
+	if p.eof() {
+		return key, "", false, nil
+	}
+	switch p.src[p.pos] {
+	case '\'':
+		val, err = p.singleQuoted()
+		return key, val, false, err
+	case '"':
+		val, err = p.doubleQuoted()
+		return key, val, true, err
+	}
+	return key, p.unquoted(), true, nil
+}
+
+func (p *dotenvParser) singleQuoted() (string, error) {
+	p.next()
+	end := strings.IndexByte(p.src[p.pos:], '\'')
+	if end < 0 {
+		return "", fmt.Errorf("unterminated single-quoted value")
+	}
+	val := p.src[p.pos : p.pos+end]
+	p.line += strings.Count(val, "\n")
+	p.pos += end + 1
+	return val, p.endLine()
+}
+
+// doubleQuoted unescapes a double-quoted value. The result is passed to Expand,
+// so an escaped \$ is written as $$ to stay literal.
+func (p *dotenvParser) doubleQuoted() (string, error) {
+	p.next()
+	var b strings.Builder
+	for !p.eof() {
+		c := p.next()
+		switch c {
+		case '"':
+			return b.String(), p.endLine()
+		case '\\':
+			if p.eof() {
+				return "", fmt.Errorf("unterminated double-quoted value")
+			}
+			switch e := p.next(); e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '$':
+				b.WriteString("$$")
+			case '"', '\\':
+				b.WriteByte(e)
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated double-quoted value")
+}
+
+// unquoted reads to the end of the line, dropping an inline comment and surrounding spaces.
+func (p *dotenvParser) unquoted() string {
+	start := p.pos
+	for !p.eof() && p.src[p.pos] != '\n' {
+		if p.src[p.pos] == '#' && (p.src[p.pos-1] == ' ' || p.src[p.pos-1] == '\t') {
+			break
+		}
+		p.pos++
+	}
+	val := strings.TrimSpace(p.src[start:p.pos])
+	p.skipLine()
+	return val
+}
+
+// rest returns the remainder of the current line, for error messages.
+func (p *dotenvParser) rest() string {
+	s, _, _ := strings.Cut(p.src[p.pos:], "\n")
+	return s
+}
+
+func isDotenvKeyChar(c byte) bool {
+	return isNameChar(c) || c == '.' || c == '-'
+}
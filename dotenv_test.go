@@ -0,0 +1,89 @@
+package ask
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	t.Setenv("ASK_TEST_HOME", "/home/app")
+
+	src := `# database settings
+export DB_HOST=localhost
+DB_PORT = 5432   # inline comment
+  export	DB_USER=app
+EMPTY=
+HASH=a#b
+DB_URL=postgres://${DB_USER}@${DB_HOST}:${DB_PORT}/${DB_NAME:-app}
+GREETING="hello\tworld\n"
+QUOTED="say \"hi\" \\ \$HOME ${ASK_TEST_HOME}"   # comment after quotes
+RAW='^\d+$ ${DB_HOST}'
+MULTI="line one
+line two"
+CERT='-----BEGIN-----
+abc
+-----END-----'
+LOG_DIR=$ASK_TEST_HOME/logs
+app.name=demo
+PRICE=5$
+`
+	got, err := ParseDotenv(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseDotenv() error = %v", err)
+	}
+
+	want := map[string]string{
+		"DB_HOST":  "localhost",
+		"DB_PORT":  "5432",
+		"DB_USER":  "app",
+		"EMPTY":    "",
+		"HASH":     "a#b",
+		"DB_URL":   "postgres://app@localhost:5432/app",
+		"GREETING": "hello\tworld\n",
+		"QUOTED":   `say "hi" \ $HOME /home/app`,
+		"RAW":      `^\d+$ ${DB_HOST}`,
+		"MULTI":    "line one\nline two",
+		"CERT":     "-----BEGIN-----\nabc\n-----END-----",
+		"LOG_DIR":  "/home/app/logs",
+		"app.name": "demo",
+		"PRICE":    "5$",
+	}
+	if !reflect.DeepEqual(got, want) {
+		for k, v := range want {
+			if got[k] != v {
+				t.Errorf("%s = %q, want %q", k, got[k], v)
+			}
+		}
+		if len(got) != len(want) {
+			t.Errorf("ParseDotenv() returned %d variables, want %d: %q", len(got), len(want), got)
+		}
+	}
+}
+
+func TestParseDotenvCRLF(t *testing.T) {
+	got, err := ParseDotenv(strings.NewReader("A=1\r\nB=\"2\"\r\n"))
+	if err != nil || got["A"] != "1" || got["B"] != "2" {
+		t.Errorf("ParseDotenv(CRLF) = %q, %v", got, err)
+	}
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"A=1\nNOEQUALS\n", "line 2: missing '='"},
+		{"=value", "line 1: invalid variable name"},
+		{"A=\"open\nB=2\n", "line 1: unterminated double-quoted value"},
+		{"A='open", "line 1: unterminated single-quoted value"},
+		{"A=\"x\" trailing", "line 1: unexpected 't' after closing quote"},
+		{"\n\nA=${REQUIRED_ASK_TEST:?missing}", "line 3: ask: expand REQUIRED_ASK_TEST: missing"},
+	}
+	for _, tt := range tests {
+		_, err := ParseDotenv(strings.NewReader(tt.src))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseDotenv(%q) error = %v, want %q", tt.src, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package ask
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/crazykun/ask/internal/redact"
+)
+
+// EnvLookup looks up a variable by name, like os.LookupEnv. It lets the env helpers,
+// Expand and Coalesce chains read from sources other than the process environment.
+//
+//	dotenv := ask.LookupMap(vars)
+//	host := ask.Coalesce(os.Getenv("HOST"), dotenv.Get("HOST"), "localhost")
+//
+// EnvLookup 按名称查找变量，签名与 os.LookupEnv 相同
+type EnvLookup func(key string) (string, bool)
+
+// LookupMap returns an EnvLookup reading from m, such as the result of ParseDotenv.
+//
+// LookupMap 将 map 适配为 EnvLookup
+func LookupMap(m map[string]string) EnvLookup {
+	return func(key string) (string, bool) {
+		v, ok := m[key]
+		return v, ok
+	}
+}
+
+// Get returns the value of the first of keys that is set and not empty, or "".
+// An empty result falls through in Coalesce and Ifelse, like a missing variable.
+//
+// Get 返回第一个已设置且非空的变量值，否则返回空字符串
+func (l EnvLookup) Get(keys ...string) string {
+	for _, key := range keys {
+		if v, _ := l(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Or returns an EnvLookup that consults l first and next for keys l does not know,
+// layering sources without touching the process environment.
+//
+//	lookup := ask.EnvLookup(os.LookupEnv).Or(ask.LookupMap(dotenv))
+//
+// Or 组合两个 EnvLookup，l 中不存在的变量再从 next 中查找
+func (l EnvLookup) Or(next EnvLookup) EnvLookup {
+	return func(key string) (string, bool) {
+		if v, ok := l(key); ok {
+			return v, true
+		}
+		return next(key)
+	}
+}
+
+// EnvOr returns the first of the environment variables keys that is set and not
+// empty, parsed as T, or def if none is set. Values are parsed like default tags
+// (see ApplyDefaults): ints, uints, floats, bools, time.Duration, url.URL,
+// encoding.TextUnmarshaler implementations and comma-separated slices.
+//
+// For every key, KEY_FILE is consulted when KEY itself is unset or empty: it names
+// a file whose content, without trailing newlines, is the value. This is how
+// Docker and Kubernetes secrets are usually mounted.
+//
+// EnvOr also returns def when a value cannot be parsed; use EnvE to detect that.
+//
+//	port := ask.EnvOr(8080, "APP_PORT", "PORT")
+//	timeout := ask.EnvOr(30*time.Second, "APP_TIMEOUT")
+//
+// EnvOr 按顺序读取环境变量并解析为 T，均未设置或解析失败时返回默认值，支持 KEY_FILE 约定
+func EnvOr[T any](def T, keys ...string) T {
+	return EnvOrFrom(os.LookupEnv, def, keys...)
+}
+
+// EnvE is like EnvOr, but reports a value that cannot be parsed, or a KEY_FILE that
+// cannot be read, instead of falling back to def. The error names the variable
+// but not its value, which may be a secret: the value is removed from the message
+// of the parse error, which stays available to errors.Is and errors.As. EnvE returns
+// def and a nil error when none of keys is set.
+//
+//	port, err := ask.EnvE(8080, "APP_PORT")
+//	if err != nil {
+//		log.Fatal(err) // ask: env APP_PORT: strconv.ParseInt: invalid syntax
+//	}
+//
+// EnvE 与 EnvOr 相同，但在解析失败或 KEY_FILE 无法读取时返回错误
+func EnvE[T any](def T, keys ...string) (T, error) {
+	return EnvEFrom(os.LookupEnv, def, keys...)
+}
+
+// EnvOrFrom is EnvOr reading variables from lookup instead of the process environment,
+// for example a parsed .env file (see ParseDotenv and LookupMap).
+//
+// EnvOrFrom 与 EnvOr 相同，但从 lookup 读取变量
+func EnvOrFrom[T any](lookup EnvLookup, def T, keys ...string) T {
+	v, err := EnvEFrom(lookup, def, keys...)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// EnvEFrom is EnvE reading variables from lookup instead of the process environment.
+// KEY_FILE is looked up in lookup as well.
+//
+// EnvEFrom 与 EnvE 相同，但从 lookup 读取变量
+func EnvEFrom[T any](lookup EnvLookup, def T, keys ...string) (T, error) {
+	for _, key := range keys {
+		s, name, err := lookupEnv(lookup, key)
+		if err != nil {
+			return def, err
+		}
+		if name == "" {
+			continue
+		}
+		var v T
+		if err := setString(reflect.ValueOf(&v).Elem(), s); err != nil {
+			return def, fmt.Errorf("ask: env %s: %w", name, redact.Value(err, s))
+		}
+		return v, nil
+	}
+	return def, nil
+}
+
+// lookupEnv returns the value of key, or the content of the file named by key_FILE,
+// together with the name of the variable that supplied it. name is empty when neither
+// is set.
+func lookupEnv(lookup EnvLookup, key string) (value, name string, err error) {
+	if s, _ := lookup(key); s != "" {
+		return s, key, nil
+	}
+	name = key + "_FILE"
+	path, _ := lookup(name)
+	if path == "" {
+		return "", "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("ask: env %s: %w", name, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), name, nil
+}
@@ -0,0 +1,195 @@
+package ask
+
+import (
+	"errors"
+	"net/netip"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnvOr(t *testing.T) {
+	t.Setenv("ASK_TEST_INT", "3000")
+	t.Setenv("ASK_TEST_HEX", "0x10")
+	t.Setenv("ASK_TEST_PADDED", "08080")
+	t.Setenv("ASK_TEST_UINT", "7")
+	t.Setenv("ASK_TEST_FLOAT", "0.5")
+	t.Setenv("ASK_TEST_BOOL", "1")
+	t.Setenv("ASK_TEST_DURATION", "1m30s")
+	t.Setenv("ASK_TEST_URL", "https://example.com:8443/api?x=1")
+	t.Setenv("ASK_TEST_LIST", "a, b,c")
+	t.Setenv("ASK_TEST_PORTS", "80,443")
+	t.Setenv("ASK_TEST_EMPTY", "")
+	t.Setenv("ASK_TEST_BAD", "abc")
+
+	if got := EnvOr(8080, "ASK_TEST_INT"); got != 3000 {
+		t.Errorf("EnvOr(int) = %d, want 3000", got)
+	}
+	if got := EnvOr(0, "ASK_TEST_HEX"); got != 16 {
+		t.Errorf("EnvOr(hex) = %d, want 16", got)
+	}
+	if got := EnvOr(0, "ASK_TEST_PADDED"); got != 8080 {
+		t.Errorf("EnvOr(08080) = %d, want 8080", got)
+	}
+	if got := EnvOr(uint16(0), "ASK_TEST_UINT"); got != 7 {
+		t.Errorf("EnvOr(uint16) = %d, want 7", got)
+	}
+	if got := EnvOr(1.0, "ASK_TEST_FLOAT"); got != 0.5 {
+		t.Errorf("EnvOr(float64) = %v, want 0.5", got)
+	}
+	if got := EnvOr(false, "ASK_TEST_BOOL"); !got {
+		t.Errorf("EnvOr(bool) = %v, want true", got)
+	}
+	if got := EnvOr(time.Second, "ASK_TEST_DURATION"); got != 90*time.Second {
+		t.Errorf("EnvOr(Duration) = %v, want 1m30s", got)
+	}
+	if got := EnvOr(url.URL{}, "ASK_TEST_URL"); got.Host != "example.com:8443" || got.Path != "/api" {
+		t.Errorf("EnvOr(url.URL) = %v", got.String())
+	}
+	if got := EnvOr[*url.URL](nil, "ASK_TEST_URL"); got == nil || got.Scheme != "https" {
+		t.Errorf("EnvOr(*url.URL) = %v", got)
+	}
+	if got := EnvOr([]string{"x"}, "ASK_TEST_LIST"); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("EnvOr([]string) = %q", got)
+	}
+	if got := EnvOr([]int(nil), "ASK_TEST_PORTS"); !reflect.DeepEqual(got, []int{80, 443}) {
+		t.Errorf("EnvOr([]int) = %v", got)
+	}
+
+	// Missing, empty and malformed values fall back to the default
+	if got := EnvOr(8080, "ASK_TEST_MISSING"); got != 8080 {
+		t.Errorf("EnvOr(missing) = %d, want 8080", got)
+	}
+	if got := EnvOr("def", "ASK_TEST_EMPTY"); got != "def" {
+		t.Errorf("EnvOr(empty) = %q, want def", got)
+	}
+	if got := EnvOr(8080, "ASK_TEST_BAD"); got != 8080 {
+		t.Errorf("EnvOr(malformed) = %d, want 8080", got)
+	}
+	if got := EnvOr[int8](1, "ASK_TEST_INT"); got != 1 {
+		t.Errorf("EnvOr(int8 overflow) = %d, want 1", got)
+	}
+	if got := EnvOr(8080); got != 8080 {
+		t.Errorf("EnvOr(no keys) = %d, want 8080", got)
+	}
+}
+
+func TestEnvOrFallbackKeys(t *testing.T) {
+	t.Setenv("ASK_TEST_SECOND", "2")
+	t.Setenv("ASK_TEST_THIRD", "3")
+	t.Setenv("ASK_TEST_EMPTY", "")
+
+	if got := EnvOr(0, "ASK_TEST_MISSING", "ASK_TEST_EMPTY", "ASK_TEST_SECOND", "ASK_TEST_THIRD"); got != 2 {
+		t.Errorf("EnvOr() = %d, want first set key 2", got)
+	}
+}
+
+func TestEnvOrFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ASK_TEST_PASSWORD_FILE", path)
+
+	if got := EnvOr("", "ASK_TEST_PASSWORD"); got != "s3cret" {
+		t.Errorf("EnvOr(_FILE) = %q, want s3cret", got)
+	}
+
+	// The variable itself takes precedence over its _FILE
+	t.Setenv("ASK_TEST_PASSWORD", "direct")
+	if got := EnvOr("", "ASK_TEST_PASSWORD"); got != "direct" {
+		t.Errorf("EnvOr(KEY and KEY_FILE) = %q, want direct", got)
+	}
+}
+
+func TestEnvE(t *testing.T) {
+	t.Setenv("ASK_TEST_PORT", "abc")
+
+	got, err := EnvE(8080, "ASK_TEST_PORT")
+	if err == nil || got != 8080 {
+		t.Fatalf("EnvE(malformed) = %d, %v; want 8080 and an error", got, err)
+	}
+	if !strings.Contains(err.Error(), "ASK_TEST_PORT") {
+		t.Errorf("EnvE() error = %q, want it to name the variable", err)
+	}
+
+	got, err = EnvE(8080, "ASK_TEST_MISSING")
+	if err != nil || got != 8080 {
+		t.Errorf("EnvE(missing) = %d, %v; want 8080, nil", got, err)
+	}
+
+	t.Setenv("ASK_TEST_TOKEN_FILE", filepath.Join(t.TempDir(), "missing"))
+	if _, err := EnvE("", "ASK_TEST_TOKEN"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("EnvE(unreadable _FILE) error = %v, want ErrNotExist", err)
+	}
+}
+
+func TestEnvERedactsValue(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "port")
+	if err := os.WriteFile(secret, []byte("hunter2-secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	lookup := LookupMap(map[string]string{
+		"X_PORT_FILE": secret,
+		"X_TIMEOUT":   "hunter2-secret",
+		"X_ADDR":      "hunter2-secret",
+	})
+
+	_, err := EnvEFrom(lookup, 8080, "X_PORT")
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("EnvEFrom(X_PORT) error = %v, want an error without the value", err)
+	}
+	if want := "ask: env X_PORT_FILE: strconv.ParseInt: invalid syntax"; err != nil && err.Error() != want {
+		t.Errorf("EnvEFrom(X_PORT) error = %q, want %q", err, want)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("EnvEFrom(X_PORT) error = %v, want it to wrap strconv.ErrSyntax", err)
+	}
+
+	if _, err := EnvEFrom(lookup, time.Second, "X_TIMEOUT"); err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("EnvEFrom(X_TIMEOUT) error = %v, want an error without the value", err)
+	}
+	if _, err := EnvEFrom(lookup, netip.Addr{}, "X_ADDR"); err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("EnvEFrom(X_ADDR) error = %v, want an error without the value", err)
+	}
+}
+
+func TestEnvLookup(t *testing.T) {
+	t.Setenv("ASK_TEST_HOST", "env.local")
+	t.Setenv("ASK_TEST_EMPTY", "")
+	dotenv := LookupMap(map[string]string{"ASK_TEST_HOST": "dotenv.local", "ASK_TEST_PORT": "9000", "ASK_TEST_EMPTY": "x"})
+
+	if got := dotenv.Get("ASK_TEST_MISSING", "ASK_TEST_PORT"); got != "9000" {
+		t.Errorf("Get() = %q, want 9000", got)
+	}
+	if got := Coalesce(EnvLookup(os.LookupEnv).Get("ASK_TEST_PORT"), dotenv.Get("ASK_TEST_PORT"), "8080"); got != "9000" {
+		t.Errorf("Coalesce(env, dotenv, default) = %q, want 9000", got)
+	}
+
+	layered := EnvLookup(os.LookupEnv).Or(dotenv)
+	if v, _ := layered("ASK_TEST_HOST"); v != "env.local" {
+		t.Errorf("layered(HOST) = %q, want env.local", v)
+	}
+	if v, _ := layered("ASK_TEST_PORT"); v != "9000" {
+		t.Errorf("layered(PORT) = %q, want 9000", v)
+	}
+	// A variable set to "" in the first layer still shadows the next one
+	if v, ok := layered("ASK_TEST_EMPTY"); v != "" || !ok {
+		t.Errorf("layered(EMPTY) = %q, %v; want \"\", true", v, ok)
+	}
+
+	if got := EnvOrFrom(dotenv, 8080, "ASK_TEST_PORT"); got != 9000 {
+		t.Errorf("EnvOrFrom() = %d, want 9000", got)
+	}
+	if _, err := EnvEFrom(LookupMap(map[string]string{"PORT": "abc"}), 8080, "PORT"); err == nil {
+		t.Error("EnvEFrom(malformed) error = nil, want error")
+	}
+	if got, _ := Expand("${ASK_TEST_HOST}:${ASK_TEST_PORT}", layered); got != "env.local:9000" {
+		t.Errorf("Expand(layered) = %q", got)
+	}
+}
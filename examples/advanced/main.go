@@ -1,15 +1,17 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
-	"strconv"
 
 	"github.com/crazykun/ask"
+	"github.com/crazykun/ask/config"
 )
 
 func main() {
-	fmt.Println("=== Ask 库高级使用示例 ===\n")
+	fmt.Println("=== Ask 库高级使用示例 ===")
+	fmt.Println()
 
 	// 1. 配置管理示例
 	configExample()
@@ -31,16 +33,28 @@ func configExample() {
 	// 模拟环境变量
 	os.Setenv("APP_PORT", "3000")
 	os.Setenv("APP_DEBUG", "true")
-
-	config := &AppConfig{
-		Host:    ask.Coalesce(os.Getenv("APP_HOST"), "localhost"),
-		Port:    ask.Ifelse(getEnvInt("APP_PORT"), 8080),
-		Debug:   ask.If(os.Getenv("APP_DEBUG") == "true", true, false),
-		LogFile: ask.Ifelse(os.Getenv("LOG_FILE"), "/var/log/app.log"),
-		Workers: ask.Ifelse(getEnvInt("WORKERS"), 4),
+	os.Setenv("APP_PASSWORD", "s3cret")
+
+	// 优先级：命令行 > 环境变量 > .env > 配置文件 > default 标签
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.Int("workers", 0, "worker count")
+	_ = fs.Parse([]string{"-workers", "8"})
+
+	var cfg AppConfig
+	prov, err := config.Load(&cfg,
+		config.Flags(fs),
+		config.Env(),
+		config.Optional(config.DotenvFile(".env")),
+		config.Optional(config.JSONFile("config.json")),
+		config.Defaults(),
+	)
+	if err != nil {
+		fmt.Println("   加载失败:", err)
+		return
 	}
 
-	fmt.Printf("   配置: %+v\n\n", config)
+	_ = prov.Table(os.Stdout, &cfg)
+	fmt.Println()
 }
 
 // API 响应构建示例
@@ -103,21 +117,29 @@ func chainedOperationsExample() {
 
 	// 复杂的条件逻辑
 	processOrder := func(order *Order) string {
+		// 条件链的各个条件都会被求值，先排除 nil 订单再访问其字段
+		if order == nil {
+			return "状态: 订单不存在 | 优先级: 普通优先级 | 建议: 请检查订单号"
+		}
+
 		// 状态检查
-		statusMsg := ask.If(order == nil, "订单不存在",
-			ask.If(order.IsPaid,
-				ask.If(order.IsShipped, "已发货", "待发货"),
-				ask.If(order.IsCancelled, "已取消", "待支付")))
+		statusMsg := ask.When(order.IsPaid && order.IsShipped, "已发货").
+			ElseIf(order.IsPaid, "待发货").
+			ElseIf(order.IsCancelled, "已取消").
+			Else("待支付")
 
 		// 优先级计算
-		priority := ask.If(order != nil && order.IsVIP, "高优先级",
-			ask.If(order != nil && order.Amount > 1000, "中优先级", "普通优先级"))
+		priority := ask.Cond(
+			ask.CaseOf(order.IsVIP, "高优先级"),
+			ask.CaseOf(order.Amount > 1000, "中优先级"),
+			ask.CaseOf(true, "普通优先级"),
+		)
 
 		// 处理建议
-		suggestion := ask.If(order == nil, "请检查订单号",
-			ask.If(order.IsCancelled, "联系客服处理",
-				ask.If(!order.IsPaid, "请尽快支付",
-					ask.If(!order.IsShipped, "正在准备发货", "请耐心等待收货"))))
+		suggestion := ask.When(order.IsCancelled, "联系客服处理").
+			ElseIf(!order.IsPaid, "请尽快支付").
+			ElseIf(!order.IsShipped, "正在准备发货").
+			Else("请耐心等待收货")
 
 		return fmt.Sprintf("状态: %s | 优先级: %s | 建议: %s", statusMsg, priority, suggestion)
 	}
@@ -138,15 +160,6 @@ func chainedOperationsExample() {
 }
 
 // 辅助函数
-func getEnvInt(key string) int {
-	if val := os.Getenv(key); val != "" {
-		if i, err := strconv.Atoi(val); err == nil {
-			return i
-		}
-	}
-	return 0
-}
-
 func extractNameFromEmail(email string) string {
 	if email == "" {
 		return ""
@@ -161,11 +174,12 @@ func extractNameFromEmail(email string) string {
 
 // 数据结构
 type AppConfig struct {
-	Host    string `json:"host"`
-	Port    int    `json:"port"`
-	Debug   bool   `json:"debug"`
-	LogFile string `json:"log_file"`
-	Workers int    `json:"workers"`
+	Host     string `json:"host" env:"APP_HOST" default:"localhost"`
+	Port     int    `json:"port" env:"APP_PORT" default:"8080"`
+	Debug    bool   `json:"debug" env:"APP_DEBUG"`
+	LogFile  string `json:"log_file" env:"LOG_FILE" default:"/var/log/app.log"`
+	Workers  int    `json:"workers" env:"WORKERS" flag:"workers" default:"4"`
+	Password string `json:"password" env:"APP_PASSWORD" ask:"secret"`
 }
 
 type User struct {
@@ -8,7 +8,8 @@ import (
 )
 
 func main() {
-	fmt.Println("=== Ask 库基本使用示例 ===\n")
+	fmt.Println("=== Ask 库基本使用示例 ===")
+	fmt.Println()
 
 	// 1. 三目运算符基本用法
 	fmt.Println("1. 三目运算符 If:")
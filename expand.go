@@ -0,0 +1,254 @@
+package ask
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// Expand replaces $NAME and ${NAME} in s with values from lookup, supporting the
+// POSIX shell parameter expansion operators:
+//
+//	${V:-word}  word if V is unset or empty, else V
+//	${V-word}   word if V is unset, else V
+//	${V:=word}  like :-, and also assigns word to V for the rest of the call
+//	${V=word}   like -, and also assigns word to V for the rest of the call
+//	${V:?msg}   an error with msg if V is unset or empty, else V
+//	${V?msg}    an error with msg if V is unset, else V
+//	${V:+word}  word if V is set and not empty, else ""
+//	${V+word}   word if V is set, else ""
+//
+// Emptiness follows IsEmpty. word may itself contain expansions, which are only
+// evaluated when word is used: "${DB_URL:-postgres://${DB_HOST:-localhost}/app}".
+// Assignments are kept in an overlay for the duration of the call; lookup's source
+// is never modified. An unset variable without an operator expands to "", and $$
+// is a literal $. A $ not followed by a name, '{' or '$' is kept as is.
+//
+//	s, err := ask.Expand("${SCHEME:-https}://${HOST:?HOST is required}", os.LookupEnv)
+//
+// Expand 按 shell 参数展开规则替换字符串中的 $NAME 与 ${NAME}，支持 :-、-、:=、=、:?、?、:+、+ 及嵌套
+func Expand(s string, lookup func(string) (string, bool)) (string, error) {
+	e := &expander{lookup: lookup}
+	return e.expand(s)
+}
+
+// ExpandEnv is Expand with the process environment as lookup.
+//
+// ExpandEnv 使用进程环境变量进行展开
+func ExpandEnv(s string) (string, error) {
+	return Expand(s, os.LookupEnv)
+}
+
+// ExpandStruct expands every string field of the struct ptr points to in place,
+// including fields of nested structs, non-nil pointers to structs or strings, and
+// elements of string slices. Fields are expanded in declaration order and share one
+// overlay, so a ${V:=word} in one field is visible to the fields after it.
+// Fields that fail to expand are left unchanged; their errors are returned as
+// *FieldError values combined with errors.Join.
+//
+// ExpandStruct 展开结构体中所有字符串字段，错误按字段汇总返回
+func ExpandStruct(ptr any, lookup func(string) (string, bool)) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ask: ExpandStruct requires a non-nil pointer to a struct, got %T", ptr)
+	}
+
+	e := &expander{lookup: lookup}
+	var errs []error
+	e.expandValue(rv.Elem(), "", map[uintptr]bool{}, &errs)
+	return errors.Join(errs...)
+}
+
+// expander holds the lookup and the assignments made by := and = during one call.
+type expander struct {
+	lookup func(string) (string, bool)
+	set    map[string]string
+}
+
+func (e *expander) get(name string) (string, bool) {
+	if v, ok := e.set[name]; ok {
+		return v, true
+	}
+	return e.lookup(name)
+}
+
+func (e *expander) expand(s string) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		switch c := s[i+1]; {
+		case c == '$':
+			b.WriteByte('$')
+			i += 2
+		case c == '{':
+			end := closingBrace(s, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("ask: expand %q: missing '}'", s[i:])
+			}
+			v, err := e.param(s[i+2 : end])
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(v)
+			i = end + 1
+		case isNameStart(c):
+			j := i + 2
+			for j < len(s) && isNameChar(s[j]) {
+				j++
+			}
+			v, _ := e.get(s[i+1 : j])
+			b.WriteString(v)
+			i = j
+		default:
+			b.WriteByte('$')
+			i++
+		}
+	}
+	return b.String(), nil
+}
+
+// param evaluates the inside of ${...}.
+func (e *expander) param(expr string) (string, error) {
+	n := 0
+	for n < len(expr) && isNameChar(expr[n]) {
+		n++
+	}
+	name := expr[:n]
+	if name == "" || !isNameStart(name[0]) {
+		return "", fmt.Errorf("ask: expand ${%s}: bad substitution", expr)
+	}
+
+	val, set := e.get(name)
+	rest := expr[n:]
+	if rest == "" {
+		return val, nil
+	}
+
+	// With a colon, an empty value counts as unset
+	colon := rest[0] == ':'
+	if colon {
+		rest = rest[1:]
+	}
+	if rest == "" {
+		return "", fmt.Errorf("ask: expand ${%s}: bad substitution", expr)
+	}
+	op, word := rest[0], rest[1:]
+	present := set && !(colon && IsEmpty(val))
+
+	switch op {
+	case '-':
+		if present {
+			return val, nil
+		}
+		return e.expand(word)
+	case '=':
+		if present {
+			return val, nil
+		}
+		w, err := e.expand(word)
+		if err != nil {
+			return "", err
+		}
+		if e.set == nil {
+			e.set = map[string]string{}
+		}
+		e.set[name] = w
+		return w, nil
+	case '?':
+		if present {
+			return val, nil
+		}
+		msg, err := e.expand(word)
+		if err != nil {
+			return "", err
+		}
+		if msg == "" {
+			msg = If(colon, "parameter null or not set", "parameter not set")
+		}
+		return "", fmt.Errorf("ask: expand %s: %s", name, msg)
+	case '+':
+		if !present {
+			return "", nil
+		}
+		return e.expand(word)
+	}
+	return "", fmt.Errorf("ask: expand ${%s}: bad substitution", expr)
+}
+
+// expandValue expands the strings in rv; active holds the pointers on the current path.
+func (e *expander) expandValue(rv reflect.Value, path string, active map[uintptr]bool, errs *[]error) {
+	switch rv.Kind() {
+	case reflect.String:
+		s, err := e.expand(rv.String())
+		if err != nil {
+			*errs = append(*errs, &FieldError{Field: path, Err: err})
+			return
+		}
+		rv.SetString(s)
+	case reflect.Struct:
+		t := rv.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); f.IsExported() {
+				e.expandValue(rv.Field(i), joinPath(path, f.Name), active, errs)
+			}
+		}
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return
+		}
+		p := rv.Pointer()
+		if active[p] {
+			return
+		}
+		active[p] = true
+		e.expandValue(rv.Elem(), path, active, errs)
+		delete(active, p)
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() != reflect.String {
+			return
+		}
+		for i := 0; i < rv.Len(); i++ {
+			e.expandValue(rv.Index(i), fmt.Sprintf("%s[%d]", path, i), active, errs)
+		}
+	}
+}
+
+// closingBrace returns the index of the '}' matching a "${" whose body starts at i,
+// or -1. Nested "${...}" are skipped.
+func closingBrace(s string, i int) int {
+	depth := 1
+	for ; i < len(s); i++ {
+		switch {
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '$':
+			i++
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '{':
+			depth++
+			i++
+		case s[i] == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || '0' <= c && c <= '9'
+}
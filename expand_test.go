@@ -0,0 +1,182 @@
+package ask
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func mapLookup(m map[string]string) func(string) (string, bool) {
+	return func(k string) (string, bool) {
+		v, ok := m[k]
+		return v, ok
+	}
+}
+
+func TestExpand(t *testing.T) {
+	lookup := mapLookup(map[string]string{
+		"HOST":  "example.com",
+		"PORT":  "8443",
+		"EMPTY": "",
+	})
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain text", "plain text"},
+		{"$HOST:$PORT", "example.com:8443"},
+		{"${HOST}x", "example.comx"},
+		{"$MISSING|${MISSING}", "|"},
+		{"cost: $$5", "cost: $5"},
+		{"50$ and $-1 and $", "50$ and $-1 and $"},
+
+		{"${HOST:-def}", "example.com"},
+		{"${EMPTY:-def}", "def"},
+		{"${MISSING:-def}", "def"},
+		{"${EMPTY-def}", ""},
+		{"${MISSING-def}", "def"},
+
+		{"${HOST:+alt}", "alt"},
+		{"${EMPTY:+alt}", ""},
+		{"${EMPTY+alt}", "alt"},
+		{"${MISSING+alt}", ""},
+
+		{"${HOST:?required}", "example.com"},
+		{"${EMPTY?required}", ""},
+
+		{"${MISSING:-${HOST}}", "example.com"},
+		{"${MISSING:-https://${OTHER:-${HOST}}:${PORT}}", "https://example.com:8443"},
+		{"${MISSING:-{}}", "{}"},
+		{"${MISSING:-a$${b}", "a${b"},
+	}
+	for _, tt := range tests {
+		got, err := Expand(tt.in, lookup)
+		if err != nil || got != tt.want {
+			t.Errorf("Expand(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestExpandAssign(t *testing.T) {
+	env := map[string]string{"EMPTY": ""}
+	lookup := mapLookup(env)
+
+	got, err := Expand("${NAME:=app}-${NAME}-${EMPTY:=x}-$EMPTY-${EMPTY2=y}$EMPTY2", lookup)
+	if err != nil || got != "app-app-x-x-yy" {
+		t.Errorf("Expand(:=) = %q, %v; want app-app-x-x-yy", got, err)
+	}
+	if _, ok := env["NAME"]; ok || env["EMPTY"] != "" {
+		t.Errorf("Expand(:=) modified the lookup source: %v", env)
+	}
+
+	// Assignments do not leak into later calls
+	if got, _ := Expand("${NAME}", lookup); got != "" {
+		t.Errorf("Expand() after := = %q, want empty", got)
+	}
+
+	// An unused default is never evaluated
+	if got, err := Expand("${EMPTY-${NAME:?boom}}", lookup); err != nil || got != "" {
+		t.Errorf("Expand(lazy word) = %q, %v; want empty, nil", got, err)
+	}
+}
+
+func TestExpandErrors(t *testing.T) {
+	lookup := mapLookup(map[string]string{"EMPTY": ""})
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"${MISSING:?MISSING is required}", "MISSING: MISSING is required"},
+		{"${EMPTY:?}", "EMPTY: parameter null or not set"},
+		{"${MISSING?}", "MISSING: parameter not set"},
+		{"${MISSING:-${INNER:?inner}}", "INNER: inner"},
+		{"${HOST", "missing '}'"},
+		{"${}", "bad substitution"},
+		{"${1X}", "bad substitution"},
+		{"${HOST:}", "bad substitution"},
+		{"${HOST#x}", "bad substitution"},
+	}
+	for _, tt := range tests {
+		got, err := Expand(tt.in, lookup)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Expand(%q) = %q, %v; want error containing %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("ASK_TEST_HOST", "db.local")
+	got, err := ExpandEnv("postgres://${ASK_TEST_HOST}:${ASK_TEST_PORT:-5432}/app")
+	if err != nil || got != "postgres://db.local:5432/app" {
+		t.Errorf("ExpandEnv() = %q, %v", got, err)
+	}
+}
+
+type expandName string
+
+type expandDB struct {
+	URL  string
+	Name expandName
+}
+
+type expandConfig struct {
+	App      string
+	Greeting string
+	Hosts    []string
+	Ports    []int
+	DB       expandDB
+	Cache    *expandDB
+	Token    *string
+	Bad      string
+	Self     *expandConfig
+	private  string
+}
+
+func TestExpandStruct(t *testing.T) {
+	token := "${TOKEN:-none}"
+	cfg := expandConfig{
+		App:      "${APP:=demo}",
+		Greeting: "hello $APP",
+		Hosts:    []string{"$HOST", "${BACKUP:-backup.local}"},
+		Ports:    []int{80},
+		DB:       expandDB{URL: "postgres://$HOST/$APP", Name: "${APP}_db"},
+		Cache:    &expandDB{URL: "redis://$HOST"},
+		Token:    &token,
+		Bad:      "${REQUIRED:?must be set}",
+		private:  "$HOST",
+	}
+	cfg.Self = &cfg
+
+	err := ExpandStruct(&cfg, mapLookup(map[string]string{"HOST": "h"}))
+
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Field != "Bad" {
+		t.Fatalf("ExpandStruct() error = %v, want *FieldError for Bad", err)
+	}
+	if cfg.Bad != "${REQUIRED:?must be set}" {
+		t.Errorf("Bad = %q, want unchanged", cfg.Bad)
+	}
+
+	want := []struct{ name, got, want string }{
+		{"App", cfg.App, "demo"},
+		{"Greeting", cfg.Greeting, "hello demo"},
+		{"Hosts[0]", cfg.Hosts[0], "h"},
+		{"Hosts[1]", cfg.Hosts[1], "backup.local"},
+		{"DB.URL", cfg.DB.URL, "postgres://h/demo"},
+		{"DB.Name", string(cfg.DB.Name), "demo_db"},
+		{"Cache.URL", cfg.Cache.URL, "redis://h"},
+		{"Token", *cfg.Token, "none"},
+		{"private", cfg.private, "$HOST"},
+	}
+	for _, w := range want {
+		if w.got != w.want {
+			t.Errorf("%s = %q, want %q", w.name, w.got, w.want)
+		}
+	}
+
+	if err := ExpandStruct(cfg, nil); err == nil {
+		t.Error("ExpandStruct(non-pointer) error = nil, want error")
+	}
+}
@@ -0,0 +1,227 @@
+package ask
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/crazykun/ask/internal/structs"
+)
+
+// Flag is a flag.Value holding a T that records whether it was set on the command line,
+// so an explicit -port=0 can be told apart from an absent flag. Values are parsed like
+// default tags (see ApplyDefaults). The zero Flag holds the zero T and is not set.
+// A Flag field may carry a `default:"..."` tag: ApplyDefaults stores the default
+// without marking the flag as set.
+//
+//	port := ask.NewFlag(8080)
+//	flag.Var(port, "port", "listen port")
+//	flag.Parse()
+//	if port.IsSet() { ... }
+//
+// Flag 记录是否被显式设置的命令行参数，可区分“显式设置为零值”与“未设置”
+type Flag[T any] struct {
+	value T
+	set   bool
+}
+
+// Convenience names for common flag types.
+//
+// 常用类型的别名
+type (
+	FlagInt      = Flag[int]
+	FlagString   = Flag[string]
+	FlagBool     = Flag[bool]
+	FlagDuration = Flag[time.Duration]
+)
+
+// NewFlag returns a Flag holding def that is not set.
+//
+// NewFlag 创建默认值为 def 且未设置的 Flag
+func NewFlag[T any](def T) *Flag[T] {
+	return &Flag[T]{value: def}
+}
+
+// Set parses s and marks the flag as set. It implements flag.Value.
+func (f *Flag[T]) Set(s string) error {
+	if err := f.setDefault(s); err != nil {
+		return err
+	}
+	f.set = true
+	return nil
+}
+
+// setDefault parses s into the value without marking the flag as set. It is how
+// default tags, ParseInto and env lookups fill a Flag field.
+func (f *Flag[T]) setDefault(s string) error {
+	var v T
+	if err := setString(reflect.ValueOf(&v).Elem(), s); err != nil {
+		return err
+	}
+	f.value = v
+	return nil
+}
+
+// String formats the current value. It implements flag.Value.
+func (f *Flag[T]) String() string {
+	if f == nil {
+		return ""
+	}
+	return fmt.Sprint(f.value)
+}
+
+// Get returns the current value. It implements flag.Getter.
+func (f *Flag[T]) Get() any {
+	return f.value
+}
+
+// IsBoolFlag lets a Flag[bool] be given as -name without a value.
+func (f *Flag[T]) IsBoolFlag() bool {
+	return reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.Bool
+}
+
+// Value returns the parsed value, or the default if the flag was not set.
+func (f *Flag[T]) Value() T {
+	return f.value
+}
+
+// IsSet reports whether Set succeeded at least once.
+func (f *Flag[T]) IsSet() bool {
+	return f.set
+}
+
+// Settable is a value that knows whether it was explicitly provided.
+// *Flag[T] implements it; NonZero and Always adapt plain values.
+//
+// Settable 可判断是否被显式提供的值
+type Settable[T any] interface {
+	Value() T
+	IsSet() bool
+}
+
+// CoalesceSet returns the value of the first Settable that is set, even if that value
+// is zero, or the zero T if none is. Unlike Coalesce, an explicit -port=0 wins.
+//
+//	port := ask.CoalesceSet(portFlag, ask.NonZero(ask.EnvOr(0, "PORT")), ask.Always(8080))
+//
+// CoalesceSet 返回第一个被显式设置的值（即使为零值）
+func CoalesceSet[T any](values ...Settable[T]) T {
+	for _, v := range values {
+		if v.IsSet() {
+			return v.Value()
+		}
+	}
+	var zero T
+	return zero
+}
+
+// settable is a Settable over a plain value.
+type settable[T any] struct {
+	value T
+	set   bool
+}
+
+func (s settable[T]) Value() T    { return s.value }
+func (s settable[T]) IsSet() bool { return s.set }
+
+// NonZero returns a Settable that counts as set when v is not zero under IsZero,
+// for sources that cannot tell "unset" from "zero", such as most environment lookups.
+//
+// NonZero 将非零值视为已设置
+func NonZero[T any](v T) Settable[T] {
+	return settable[T]{value: v, set: !isZero(v)}
+}
+
+// Always returns a Settable that is always set, typically the final default.
+//
+// Always 返回始终视为已设置的值，通常用作最终默认值
+func Always[T any](v T) Settable[T] {
+	return settable[T]{value: v, set: true}
+}
+
+// NewFlagSet defines a flag for every field of the struct ptr points to that has a
+// `flag:"name"` tag, including fields of nested structs other than single values
+// such as encoding.TextUnmarshaler implementations. Each flag writes straight into
+// its field, parsed like default tags; a field whose pointer already implements
+// flag.Value, such as a *Flag[T], is registered as is. The usage text comes from the
+// `desc` tag and the default shown is the field's current value, so call ApplyDefaults
+// first to document defaults. Bool fields may be given as -name without a value.
+//
+//	type Options struct {
+//		Port    int           `flag:"port" desc:"listen port" default:"8080"`
+//		Timeout time.Duration `flag:"timeout" desc:"request timeout" default:"30s"`
+//	}
+//
+//	var opts Options
+//	_ = ask.ApplyDefaults(&opts)
+//	fs, err := ask.NewFlagSet("server", &opts, flag.ExitOnError)
+//	fs.Parse(os.Args[1:])
+//
+// NewFlagSet 根据结构体的 flag 与 desc 标签生成 flag.FlagSet，参数值直接写入对应字段
+func NewFlagSet(name string, ptr any, handling flag.ErrorHandling) (*flag.FlagSet, error) {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("ask: NewFlagSet requires a non-nil pointer to a struct, got %T", ptr)
+	}
+	fs := flag.NewFlagSet(name, handling)
+	if err := defineFlags(fs, rv.Elem(), ""); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+var flagValueType = reflect.TypeOf((*flag.Value)(nil)).Elem()
+
+func defineFlags(fs *flag.FlagSet, rv reflect.Value, prefix string) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		path := prefix + f.Name
+
+		name := f.Tag.Get("flag")
+		if name == "" || name == "-" {
+			if fv.Kind() == reflect.Struct && !structs.IsLeaf(fv.Type()) {
+				if err := defineFlags(fs, fv, path+"."); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if fs.Lookup(name) != nil {
+			return &FieldError{Field: path, Err: fmt.Errorf("flag -%s is already defined", name)}
+		}
+
+		if fv.Addr().Type().Implements(flagValueType) {
+			fs.Var(fv.Addr().Interface().(flag.Value), name, f.Tag.Get("desc"))
+		} else {
+			fs.Var(fieldFlag{fv}, name, f.Tag.Get("desc"))
+		}
+	}
+	return nil
+}
+
+// fieldFlag is a flag.Value writing into a struct field.
+type fieldFlag struct {
+	rv reflect.Value
+}
+
+func (f fieldFlag) Set(s string) error {
+	return setString(f.rv, s)
+}
+
+func (f fieldFlag) String() string {
+	// flag.PrintDefaults calls String on a zero fieldFlag to detect zero defaults
+	if !f.rv.IsValid() {
+		return ""
+	}
+	return fmt.Sprint(f.rv.Interface())
+}
+
+func (f fieldFlag) IsBoolFlag() bool {
+	return f.rv.IsValid() && f.rv.Kind() == reflect.Bool
+}
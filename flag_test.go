@@ -0,0 +1,203 @@
+package ask
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	port := NewFlag(8080)
+	name := new(FlagString)
+	debug := new(FlagBool)
+	timeout := new(FlagDuration)
+	hosts := new(Flag[[]string])
+	fs.Var(port, "port", "")
+	fs.Var(name, "name", "")
+	fs.Var(debug, "debug", "")
+	fs.Var(timeout, "timeout", "")
+	fs.Var(hosts, "hosts", "")
+
+	if err := fs.Parse([]string{"-port=0", "-debug", "-timeout", "5s", "-hosts", "a,b"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if !port.IsSet() || port.Value() != 0 {
+		t.Errorf("port = %d, set %v; want explicit 0", port.Value(), port.IsSet())
+	}
+	if name.IsSet() || name.Value() != "" {
+		t.Errorf("name = %q, set %v; want unset", name.Value(), name.IsSet())
+	}
+	if !debug.IsSet() || !debug.Value() {
+		t.Errorf("debug = %v, set %v; want true", debug.Value(), debug.IsSet())
+	}
+	if timeout.Value() != 5*time.Second {
+		t.Errorf("timeout = %v, want 5s", timeout.Value())
+	}
+	if !reflect.DeepEqual(hosts.Value(), []string{"a", "b"}) {
+		t.Errorf("hosts = %q", hosts.Value())
+	}
+	if port.String() != "0" || port.Get() != 0 {
+		t.Errorf("port String/Get = %q, %v", port.String(), port.Get())
+	}
+
+	unset := NewFlag(8080)
+	if unset.IsSet() || unset.Value() != 8080 {
+		t.Errorf("NewFlag(8080) = %d, set %v; want default, unset", unset.Value(), unset.IsSet())
+	}
+	if err := unset.Set("abc"); err == nil || unset.IsSet() {
+		t.Errorf("Set(abc) = %v, set %v; want error, still unset", err, unset.IsSet())
+	}
+}
+
+func TestCoalesceSet(t *testing.T) {
+	explicitZero := NewFlag(1)
+	_ = explicitZero.Set("0")
+	unset := NewFlag(1)
+
+	tests := []struct {
+		name   string
+		values []Settable[int]
+		want   int
+	}{
+		{"explicit zero wins", []Settable[int]{explicitZero, NonZero(3000), Always(8080)}, 0},
+		{"unset flag falls through", []Settable[int]{unset, NonZero(3000), Always(8080)}, 3000},
+		{"zero env falls through", []Settable[int]{unset, NonZero(0), Always(8080)}, 8080},
+		{"Always zero wins", []Settable[int]{unset, Always(0), Always(8080)}, 0},
+		{"none set", []Settable[int]{unset, NonZero(0)}, 0},
+		{"empty", nil, 0},
+	}
+	for _, tt := range tests {
+		if got := CoalesceSet(tt.values...); got != tt.want {
+			t.Errorf("%s: CoalesceSet() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+type flagServer struct {
+	Host string `flag:"host" desc:"listen host"`
+	Port int    `flag:"port" desc:"listen port"`
+}
+
+type flagOptions struct {
+	Server  flagServer
+	Debug   bool              `flag:"debug" desc:"enable debug output"`
+	Timeout time.Duration     `flag:"timeout" desc:"request timeout" default:"30s"`
+	Tags    []string          `flag:"tags"`
+	Workers Flag[int]         `flag:"workers" desc:"worker count" default:"4"`
+	Labels  map[string]string `flag:"-"`
+	NoFlag  string
+	private string `flag:"private"`
+}
+
+func TestNewFlagSet(t *testing.T) {
+	var opts flagOptions
+	if err := ApplyDefaults(&opts); err != nil {
+		t.Fatal(err)
+	}
+	fs, err := NewFlagSet("test", &opts, flag.ContinueOnError)
+	if err != nil {
+		t.Fatalf("NewFlagSet() error = %v", err)
+	}
+
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) { names = append(names, f.Name) })
+	want := []string{"debug", "host", "port", "tags", "timeout", "workers"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("flags = %q, want %q", names, want)
+	}
+	if f := fs.Lookup("port"); f.Usage != "listen port" {
+		t.Errorf("port usage = %q", f.Usage)
+	}
+	if f := fs.Lookup("timeout"); f.DefValue != "30s" {
+		t.Errorf("timeout default = %q, want 30s", f.DefValue)
+	}
+	if f := fs.Lookup("workers"); f.DefValue != "4" || opts.Workers.IsSet() {
+		t.Errorf("workers default = %q, set %v; want 4, unset", f.DefValue, opts.Workers.IsSet())
+	}
+
+	err = fs.Parse([]string{"-host", "0.0.0.0", "-port=9000", "-debug", "-tags=a,b", "-workers=0"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if opts.Server.Host != "0.0.0.0" || opts.Server.Port != 9000 || !opts.Debug {
+		t.Errorf("opts = %+v", opts)
+	}
+	if !reflect.DeepEqual(opts.Tags, []string{"a", "b"}) {
+		t.Errorf("Tags = %q", opts.Tags)
+	}
+	if opts.Timeout != 30*time.Second {
+		t.Errorf("Timeout = %v, want default kept", opts.Timeout)
+	}
+	if !opts.Workers.IsSet() || opts.Workers.Value() != 0 {
+		t.Errorf("Workers = %d, set %v; want explicit 0", opts.Workers.Value(), opts.Workers.IsSet())
+	}
+
+	// Defaults never overwrite or mark a Flag: an explicit 0 stays, an unset Flag stays unset
+	if err := ApplyDefaults(&opts); err != nil || !opts.Workers.IsSet() || opts.Workers.Value() != 0 {
+		t.Errorf("ApplyDefaults() after -workers=0: Workers = %d, set %v, err %v", opts.Workers.Value(), opts.Workers.IsSet(), err)
+	}
+	var fresh flagOptions
+	if err := ParseInto(&fresh.Workers, "7"); err != nil || fresh.Workers.Value() != 7 || fresh.Workers.IsSet() {
+		t.Errorf("ParseInto(Flag) = %d, set %v, err %v; want 7, unset", fresh.Workers.Value(), fresh.Workers.IsSet(), err)
+	}
+
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse([]string{"-port=abc"}); err == nil {
+		t.Error("Parse(-port=abc) error = nil, want error")
+	}
+
+	var b strings.Builder
+	fs.SetOutput(&b)
+	fs.PrintDefaults()
+	if !strings.Contains(b.String(), "request timeout (default 30s)") {
+		t.Errorf("PrintDefaults() =\n%s", b.String())
+	}
+}
+
+// flagEndpoint is parsed from "host:port" as a whole, so its own flag tag is never read.
+type flagEndpoint struct {
+	Host string `flag:"host"`
+	Port string
+}
+
+func (e *flagEndpoint) UnmarshalText(b []byte) error {
+	e.Host, e.Port, _ = strings.Cut(string(b), ":")
+	return nil
+}
+
+func TestNewFlagSetTextLeaf(t *testing.T) {
+	var opts struct {
+		Host     string       `flag:"host"`
+		Upstream flagEndpoint `flag:"upstream"`
+		Fallback flagEndpoint
+	}
+	fs, err := NewFlagSet("test", &opts, flag.ContinueOnError)
+	if err != nil {
+		t.Fatalf("NewFlagSet() error = %v; want the untagged TextUnmarshaler skipped", err)
+	}
+	if err := fs.Parse([]string{"-upstream=db:5432"}); err != nil || opts.Upstream != (flagEndpoint{Host: "db", Port: "5432"}) {
+		t.Errorf("Parse(-upstream) = %+v, %v", opts.Upstream, err)
+	}
+}
+
+func TestNewFlagSetErrors(t *testing.T) {
+	if _, err := NewFlagSet("test", flagOptions{}, flag.ContinueOnError); err == nil {
+		t.Error("NewFlagSet(non-pointer) error = nil, want error")
+	}
+
+	var dup struct {
+		A string `flag:"name"`
+		B string `flag:"name"`
+	}
+	_, err := NewFlagSet("test", &dup, flag.ContinueOnError)
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Field != "B" {
+		t.Errorf("NewFlagSet(duplicate) error = %v, want *FieldError for B", err)
+	}
+}
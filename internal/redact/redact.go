@@ -0,0 +1,38 @@
+// Package redact removes parsed values from error messages, so that parse errors
+// from package ask and its subpackages never leak secrets read from the environment.
+package redact
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// redactedError is a parse error whose message no longer contains the parsed value.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// Value returns err with value removed from its message. strconv errors are
+// reduced to the function and the cause; other messages have the quoted value
+// replaced, and are dropped entirely if the value still appears in them.
+func Value(err error, value string) error {
+	if value == "" {
+		return err
+	}
+	var msg string
+	var ne *strconv.NumError
+	if errors.As(err, &ne) {
+		msg = "strconv." + ne.Func + ": " + ne.Err.Error()
+	} else {
+		msg = strings.ReplaceAll(err.Error(), strconv.Quote(value), `"******"`)
+	}
+	if strings.Contains(msg, value) {
+		msg = "invalid value"
+	}
+	return &redactedError{msg: msg, err: err}
+}
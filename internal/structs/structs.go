@@ -0,0 +1,55 @@
+// Package structs holds the struct-walking rules shared by package ask and its
+// subpackages, so that they agree on which fields a struct has.
+package structs
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+)
+
+var (
+	zeroerType          = reflect.TypeOf((*interface{ IsZero() bool })(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Options returns the comma-separated options of the `ask:"..."` tag on f,
+// for example ["required", "append"] for `ask:"required,append"`.
+func Options(f reflect.StructField) []string {
+	tag, ok := f.Tag.Lookup("ask")
+	if !ok || tag == "" {
+		return nil
+	}
+	opts := strings.Split(tag, ",")
+	for i := range opts {
+		opts[i] = strings.TrimSpace(opts[i])
+	}
+	return opts
+}
+
+// HasOption reports whether opt is one of the options of the ask tag on f.
+func HasOption(f reflect.StructField, opt string) bool {
+	for _, v := range Options(f) {
+		if v == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLeaf reports whether struct type t is a single value rather than a group of
+// fields to walk: types with their own IsZero method (time.Time, decimals), types
+// parsed as a whole from text (encoding.TextUnmarshaler) and types without exported
+// fields.
+func IsLeaf(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	if t.Implements(zeroerType) || pt.Implements(zeroerType) || pt.Implements(textUnmarshalerType) {
+		return true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
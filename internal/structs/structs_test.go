@@ -0,0 +1,52 @@
+package structs
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type endpoint struct {
+	Host string
+	Port string
+}
+
+func (e *endpoint) UnmarshalText(b []byte) error { return nil }
+
+type group struct {
+	Name  string `ask:"required, secret"`
+	Plain string
+}
+
+func TestIsLeaf(t *testing.T) {
+	tests := []struct {
+		typ  reflect.Type
+		want bool
+	}{
+		{reflect.TypeOf(time.Time{}), true},
+		{reflect.TypeOf(netip.Addr{}), true},
+		{reflect.TypeOf(endpoint{}), true},
+		{reflect.TypeOf(struct{ x int }{}), true},
+		{reflect.TypeOf(group{}), false},
+	}
+	for _, tt := range tests {
+		if got := IsLeaf(tt.typ); got != tt.want {
+			t.Errorf("IsLeaf(%v) = %v, want %v", tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	name, _ := reflect.TypeOf(group{}).FieldByName("Name")
+	plain, _ := reflect.TypeOf(group{}).FieldByName("Plain")
+	if got := Options(name); !reflect.DeepEqual(got, []string{"required", "secret"}) {
+		t.Errorf("Options(Name) = %q", got)
+	}
+	if !HasOption(name, "secret") || HasOption(name, "append") || HasOption(plain, "secret") {
+		t.Error("HasOption() mismatch")
+	}
+	if Options(plain) != nil {
+		t.Errorf("Options(Plain) = %q, want nil", Options(plain))
+	}
+}
@@ -0,0 +1,139 @@
+package ask
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/crazykun/ask/internal/structs"
+)
+
+// DecodeJSONWithDefaults decodes the JSON document in r into the struct ptr points to,
+// then applies `default:"..."` tags (see ApplyDefaults) to the fields whose keys were
+// absent from the document. Presence is tracked through nested objects, pointers to
+// structs and arrays of objects, so {"limit": 0} keeps the explicit zero while {} gets
+// the default. Structs decoded as a single value, such as encoding.TextUnmarshaler
+// implementations, are kept as decoded. The rules are:
+//
+//   - an absent key gets its default, as does every field below it
+//   - an explicit null counts as absent and gets its default
+//   - any other explicit value, zeros included, is kept as decoded
+//
+// Keys are matched to fields like encoding/json does: by json tag name or field name,
+// preferring an exact match over a case-insensitive one. Fields tagged json:"-" are
+// never present. Structs with a SetDefaults() method have it called after their
+// fields are filled, as with ApplyDefaults.
+//
+// Decoding errors are returned as is. Default tags that cannot be parsed are reported
+// as *FieldError values combined with errors.Join, with the decoded value kept.
+//
+//	type ListRequest struct {
+//		Limit  int    `json:"limit" default:"20"`
+//		Cursor string `json:"cursor"`
+//	}
+//
+//	var req ListRequest
+//	err := ask.DecodeJSONWithDefaults(r.Body, &req) // {"limit": 0} → Limit 0, {} → Limit 20
+//
+// DecodeJSONWithDefaults 解码 JSON 并仅为文档中缺失（或为 null）的字段应用 default 标签，显式零值会被保留
+func DecodeJSONWithDefaults(r io.Reader, ptr any) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ask: DecodeJSONWithDefaults requires a non-nil pointer to a struct, got %T", ptr)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, ptr); err != nil {
+		return err
+	}
+	// The document is known to be valid, so this only builds the presence tree
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	obj, _ := doc.(map[string]any)
+	var errs []error
+	presentDefaults(rv.Elem(), obj, "", map[reflect.Type]bool{}, &errs)
+	return errors.Join(errs...)
+}
+
+// presentDefaults applies defaults to the fields of rv missing from obj, the JSON object
+// it was decoded from, and descends into the fields that are present.
+func presentDefaults(rv reflect.Value, obj map[string]any, prefix string, active map[reflect.Type]bool, errs *[]error) {
+	t := rv.Type()
+	active[t] = true
+	defer delete(active, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fv := rv.Field(i)
+		name, hasJSON := jsonName(f)
+
+		// Embedded structs without a json name share the keys of their parent; a nil
+		// embedded pointer held none of them and is filled like an absent field below
+		if f.Anonymous && !hasJSON {
+			if fv.Kind() == reflect.Struct {
+				presentDefaults(fv, obj, prefix, active, errs)
+				continue
+			}
+			if fv.Kind() == reflect.Ptr && !fv.IsNil() && fv.Elem().Kind() == reflect.Struct && !active[fv.Type().Elem()] {
+				presentDefaults(fv.Elem(), obj, prefix, active, errs)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		path := prefix + f.Name
+
+		val, ok := jsonKey(obj, name)
+		if !ok || val == nil || f.Tag.Get("json") == "-" {
+			applyFieldDefaults(f, fv, path, active, errs)
+			continue
+		}
+
+		switch {
+		case fv.Kind() == reflect.Struct && !structs.IsLeaf(fv.Type()):
+			if m, ok := val.(map[string]any); ok {
+				presentDefaults(fv, m, path+".", active, errs)
+			}
+		case fv.Kind() == reflect.Ptr && !fv.IsNil() && fv.Elem().Kind() == reflect.Struct && !structs.IsLeaf(fv.Type().Elem()):
+			if m, ok := val.(map[string]any); ok {
+				presentDefaults(fv.Elem(), m, path+".", active, errs)
+			}
+		case fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array:
+			arr, ok := val.([]any)
+			if !ok || fv.Type().Elem().Kind() != reflect.Struct || structs.IsLeaf(fv.Type().Elem()) {
+				continue
+			}
+			for j := 0; j < fv.Len() && j < len(arr); j++ {
+				if m, ok := arr[j].(map[string]any); ok {
+					presentDefaults(fv.Index(j), m, fmt.Sprintf("%s[%d].", path, j), active, errs)
+				}
+			}
+		}
+	}
+
+	callSetDefaults(rv)
+}
+
+// jsonKey finds the value for name in obj, preferring an exact key over a
+// case-insensitive one, like encoding/json.
+func jsonKey(obj map[string]any, name string) (any, bool) {
+	if v, ok := obj[name]; ok {
+		return v, true
+	}
+	for k, v := range obj {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return nil, false
+}
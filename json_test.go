@@ -0,0 +1,189 @@
+package ask
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type jsonPaging struct {
+	Limit  int    `json:"limit" default:"20"`
+	Order  string `json:"order" default:"asc"`
+	Cursor string `json:"cursor"`
+}
+
+type jsonFilter struct {
+	Field string `json:"field" default:"id"`
+	Op    string `json:"op" default:"eq"`
+}
+
+type jsonMeta struct {
+	Trace bool `default:"true"`
+}
+
+type jsonRequest struct {
+	jsonMeta
+	Query    string        `json:"query" default:"*"`
+	Enabled  *bool         `json:"enabled" default:"true"`
+	Timeout  time.Duration `json:"timeout" default:"30s"`
+	Paging   jsonPaging    `json:"paging"`
+	Next     *jsonPaging   `json:"next"`
+	Filters  []jsonFilter  `json:"filters"`
+	Internal string        `json:"-" default:"internal"`
+	Derived  string        `json:"derived"`
+}
+
+func (r *jsonRequest) SetDefaults() {
+	if r.Derived == "" {
+		r.Derived = "from-" + r.Query
+	}
+}
+
+func TestDecodeJSONWithDefaults(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		check func(t *testing.T, r jsonRequest)
+	}{
+		{"empty object gets every default", `{}`, func(t *testing.T, r jsonRequest) {
+			if r.Query != "*" || r.Enabled == nil || !*r.Enabled || r.Timeout != 30*time.Second {
+				t.Errorf("top-level defaults not applied: %+v", r)
+			}
+			if r.Paging.Limit != 20 || r.Paging.Order != "asc" {
+				t.Errorf("Paging = %+v, want defaults", r.Paging)
+			}
+			if r.Next == nil || r.Next.Limit != 20 {
+				t.Errorf("Next = %+v, want allocated with defaults", r.Next)
+			}
+			if !r.Trace || r.Internal != "internal" || r.Derived != "from-*" {
+				t.Errorf("Trace/Internal/Derived = %v, %q, %q", r.Trace, r.Internal, r.Derived)
+			}
+		}},
+		{"explicit zeros are kept", `{"query": "", "enabled": false, "timeout": 0, "paging": {"limit": 0}, "Trace": false}`, func(t *testing.T, r jsonRequest) {
+			if r.Query != "" || r.Enabled == nil || *r.Enabled || r.Timeout != 0 {
+				t.Errorf("explicit zeros overwritten: %+v", r)
+			}
+			if r.Paging.Limit != 0 || r.Paging.Order != "asc" {
+				t.Errorf("Paging = %+v, want Limit kept at 0 and Order defaulted", r.Paging)
+			}
+			if r.Trace {
+				t.Errorf("Trace = true, want explicit false kept")
+			}
+		}},
+		{"explicit nulls count as absent", `{"query": null, "enabled": null, "paging": null, "next": null}`, func(t *testing.T, r jsonRequest) {
+			if r.Query != "*" || r.Enabled == nil || !*r.Enabled {
+				t.Errorf("nulls not defaulted: %+v", r)
+			}
+			if r.Paging.Limit != 20 || r.Next == nil || r.Next.Limit != 20 {
+				t.Errorf("null objects not defaulted: %+v, %+v", r.Paging, r.Next)
+			}
+		}},
+		{"nested objects and arrays", `{"next": {"limit": 0}, "filters": [{"field": "name"}, {"op": ""}]}`, func(t *testing.T, r jsonRequest) {
+			if r.Next.Limit != 0 || r.Next.Order != "asc" {
+				t.Errorf("Next = %+v", r.Next)
+			}
+			if len(r.Filters) != 2 || r.Filters[0] != (jsonFilter{"name", "eq"}) || r.Filters[1] != (jsonFilter{"id", ""}) {
+				t.Errorf("Filters = %+v", r.Filters)
+			}
+		}},
+		{"keys match case-insensitively", `{"QUERY": "", "Paging": {"Limit": 0}}`, func(t *testing.T, r jsonRequest) {
+			if r.Query != "" || r.Paging.Limit != 0 {
+				t.Errorf("case-insensitive keys not treated as present: %+v", r)
+			}
+		}},
+		{"json:\"-\" is never present", `{"Internal": "x"}`, func(t *testing.T, r jsonRequest) {
+			if r.Internal != "internal" {
+				t.Errorf("Internal = %q, want default", r.Internal)
+			}
+		}},
+		{"null document", `null`, func(t *testing.T, r jsonRequest) {
+			if r.Query != "*" || r.Paging.Limit != 20 {
+				t.Errorf("defaults not applied: %+v", r)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r jsonRequest
+			if err := DecodeJSONWithDefaults(strings.NewReader(tt.input), &r); err != nil {
+				t.Fatalf("DecodeJSONWithDefaults() error = %v", err)
+			}
+			tt.check(t, r)
+		})
+	}
+}
+
+type jsonBase struct {
+	Limit int    `json:"limit" default:"20"`
+	Order string `json:"order" default:"asc"`
+}
+
+func TestDecodeJSONWithDefaultsEmbeddedPointer(t *testing.T) {
+	// encoding/json only allocates embedded pointers to exported types
+	type Base = jsonBase
+	type listRequest struct {
+		*Base
+		Name string `json:"name" default:"all"`
+	}
+
+	var r listRequest
+	if err := DecodeJSONWithDefaults(strings.NewReader(`{"limit": 0}`), &r); err != nil {
+		t.Fatalf("DecodeJSONWithDefaults() error = %v", err)
+	}
+	if r.Base == nil || r.Limit != 0 || r.Order != "asc" || r.Name != "all" {
+		t.Errorf("r = %+v, Base = %+v; want Limit kept at 0 and Order defaulted", r, r.Base)
+	}
+
+	var empty listRequest
+	if err := DecodeJSONWithDefaults(strings.NewReader(`{}`), &empty); err != nil {
+		t.Fatalf("DecodeJSONWithDefaults() error = %v", err)
+	}
+	if empty.Base == nil || empty.Limit != 20 || empty.Order != "asc" {
+		t.Errorf("Base = %+v; want allocated with defaults", empty.Base)
+	}
+}
+
+func TestDecodeJSONWithDefaultsTextLeaf(t *testing.T) {
+	var r struct {
+		Primary defaultsAddr   `json:"primary"`
+		Replica defaultsAddr   `json:"replica" default:"replica:5432"`
+		Peers   []defaultsAddr `json:"peers"`
+	}
+	input := `{"primary": "db", "peers": ["a"]}`
+	if err := DecodeJSONWithDefaults(strings.NewReader(input), &r); err != nil {
+		t.Fatalf("DecodeJSONWithDefaults() error = %v", err)
+	}
+	if r.Primary != (defaultsAddr{Host: "db"}) || r.Peers[0] != (defaultsAddr{Host: "a"}) {
+		t.Errorf("Primary = %+v, Peers = %+v; want kept as decoded", r.Primary, r.Peers)
+	}
+	if r.Replica != (defaultsAddr{Host: "replica", Port: "5432"}) {
+		t.Errorf("Replica = %+v; want the parsed default", r.Replica)
+	}
+}
+
+func TestDecodeJSONWithDefaultsErrors(t *testing.T) {
+	var r jsonRequest
+	if err := DecodeJSONWithDefaults(strings.NewReader(`{"query": 1}`), &r); err == nil {
+		t.Error("DecodeJSONWithDefaults(type mismatch) error = nil, want error")
+	}
+	if err := DecodeJSONWithDefaults(strings.NewReader(`{`), &r); err == nil {
+		t.Error("DecodeJSONWithDefaults(invalid) error = nil, want error")
+	}
+	if err := DecodeJSONWithDefaults(strings.NewReader(`{}`), r); err == nil {
+		t.Error("DecodeJSONWithDefaults(non-pointer) error = nil, want error")
+	}
+
+	var bad struct {
+		N int `json:"n" default:"abc"`
+		M int `json:"m" default:"abc"`
+	}
+	err := DecodeJSONWithDefaults(strings.NewReader(`{"m": 5}`), &bad)
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Field != "N" {
+		t.Errorf("DecodeJSONWithDefaults(bad default) error = %v, want *FieldError for N", err)
+	}
+	if bad.M != 5 {
+		t.Errorf("M = %d, want decoded value kept", bad.M)
+	}
+}
@@ -0,0 +1,62 @@
+package ask
+
+// The package offers the three logical value operators known from JavaScript:
+//
+//	Coalesce(a, b, c)  a || b || c  first non-zero value, else the zero value
+//	Nullish(a, b, c)   a ?? b ?? c  first non-nil value, else nil
+//	And(a, b, c)       a && b && c  first zero value, else the last value
+//
+// Coalesce and And use the rules of IsZero, so 0, "", false, nil and empty slices and
+// maps all count as zero. Nullish only skips nil pointers, interfaces, maps, slices,
+// channels and funcs, keeping explicit values such as 0, "" and false.
+
+// Nullish returns the first value that is not nil, like the ?? operator. Only nil
+// pointers, interfaces, maps, slices, channels and funcs are skipped, so unlike
+// Coalesce it keeps 0, "" and false, and an empty but non-nil slice:
+//
+//	limit := ask.Nullish(req.Limit, cfg.Limit, &defaultLimit) // *int; a *int to 0 is kept
+//
+// It is equivalent to CoalesceWith(NilOnly, values...). If every value is nil,
+// Nullish returns the zero value of T.
+//
+// Nullish 返回第一个非 nil 的值（类似 ?? 运算符），0、""、false 均视为有效值
+func Nullish[T any](values ...T) T {
+	for _, v := range values {
+		if !IsNil(v) {
+			return v
+		}
+	}
+	var zero T
+	return zero
+}
+
+// NullishFunc is the lazy counterpart of Nullish.
+// Functions are called in order and evaluation stops at the first non-nil result.
+//
+// NullishFunc 惰性版本的 Nullish，遇到第一个非 nil 的结果即停止
+func NullishFunc[T any](fns ...func() T) T {
+	for _, fn := range fns {
+		if v := fn(); !IsNil(v) {
+			return v
+		}
+	}
+	var zero T
+	return zero
+}
+
+// And returns the first zero value, or the last value if none is zero, like the &&
+// operator. Zero is decided as in IsZero. And() with no values returns the zero value of T.
+//
+//	fullName := ask.And(first, last, first+" "+last) // "" unless both names are set
+//
+// And 返回第一个零值，若都非零则返回最后一个值（类似 && 运算符）
+func And[T any](values ...T) T {
+	var last T
+	for _, v := range values {
+		if isZero(v) {
+			return v
+		}
+		last = v
+	}
+	return last
+}
@@ -0,0 +1,77 @@
+package ask
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNullish(t *testing.T) {
+	zero, five := 0, 5
+	if got := Nullish(nil, &zero, &five); got != &zero {
+		t.Errorf("Nullish(nil, &0, &5) = %v, want &0", got)
+	}
+	if got := Nullish(0, 42); got != 0 {
+		t.Errorf("Nullish(0, 42) = %d, want 0", got)
+	}
+	if got := Nullish("", "x"); got != "" {
+		t.Errorf("Nullish(\"\", x) = %q, want empty", got)
+	}
+	if got := Nullish(false, true); got {
+		t.Error("Nullish(false, true) = true, want false")
+	}
+	if got := Nullish(nil, []int{}, []int{1}); got == nil || len(got) != 0 {
+		t.Errorf("Nullish(nil, [], [1]) = %v, want []", got)
+	}
+	if got := Nullish[map[string]int](nil, nil); got != nil {
+		t.Errorf("Nullish(nil, nil) = %v, want nil", got)
+	}
+	boom := errors.New("boom")
+	var typedNil *resultErr
+	if got := Nullish[error](nil, typedNil, boom); got != boom {
+		t.Errorf("Nullish(nil, typed nil, boom) = %v, want boom", got)
+	}
+	if got := Nullish[*int](); got != nil {
+		t.Errorf("Nullish() = %v, want nil", got)
+	}
+}
+
+func TestNullishFunc(t *testing.T) {
+	calls := 0
+	fn := func(v []int) func() []int {
+		return func() []int { calls++; return v }
+	}
+	got := NullishFunc(fn(nil), fn([]int{}), fn([]int{1}))
+	if got == nil || len(got) != 0 || calls != 2 {
+		t.Errorf("NullishFunc() = %v after %d calls, want [] after 2", got, calls)
+	}
+	if got := NullishFunc[*int](); got != nil {
+		t.Errorf("NullishFunc() = %v, want nil", got)
+	}
+}
+
+func TestAnd(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   string
+	}{
+		{"all set", []string{"a", "b", "c"}, "c"},
+		{"first zero", []string{"", "b"}, ""},
+		{"middle zero", []string{"a", "", "c"}, ""},
+		{"single", []string{"a"}, "a"},
+		{"none", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := And(tt.values...); got != tt.want {
+			t.Errorf("And(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if got := And(3, 0, 5); got != 0 {
+		t.Errorf("And(3, 0, 5) = %d, want 0", got)
+	}
+	// Empty slices count as zero, as in IsZero
+	if got := And([]int{1}, []int{}, []int{2}); got == nil || len(got) != 0 {
+		t.Errorf("And([1], [], [2]) = %v, want []", got)
+	}
+}
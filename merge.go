@@ -0,0 +1,110 @@
+package ask
+
+import (
+	"reflect"
+
+	"github.com/crazykun/ask/internal/structs"
+)
+
+// CoalesceStruct merges struct layers field by field, taking the first non-zero value
+// for each field. It is Coalesce applied to every field of T:
+//
+//	cfg := ask.CoalesceStruct(flagCfg, envCfg, fileCfg, defaultCfg)
+//
+// Nested structs are merged recursively; see MergeInto for the rules and the
+// `ask:"..."` tag options that change them. The layers are not modified.
+//
+// CoalesceStruct 按字段合并多个结构体，每个字段取第一个非零值，嵌套结构体递归合并
+func CoalesceStruct[T any](layers ...T) T {
+	var out T
+	if len(layers) == 0 {
+		return out
+	}
+	out = layers[0]
+	MergeInto(&out, layers[1:]...)
+	return out
+}
+
+// MergeInto fills the zero fields of dst from src, in order, so dst keeps the highest
+// precedence and each src layer only fills what is still unset. Zeroness follows IsZero.
+//
+// By default nested structs are merged field by field, while every other field
+// (including pointers, slices and maps) is taken as a whole from the first layer
+// where it is non-zero. Structs with their own IsZero method, such as time.Time,
+// structs parsed from text (encoding.TextUnmarshaler) and structs without exported
+// fields are treated as single values, so two layers are never mixed inside them.
+// The `ask` struct tag changes the strategy for a field:
+//
+//	ask:"keep"      keep the value of dst, even if it is zero
+//	ask:"-"         same as keep
+//	ask:"override"  the value is taken as a whole from the first layer where it
+//	                is non-zero; nested structs are not merged field by field
+//	ask:"append"    slices are concatenated in layer order; maps are united,
+//	                with earlier layers winning on duplicate keys
+//
+// Unexported fields are never modified. MergeInto panics if T is not a struct type.
+//
+// MergeInto 将 src 中的值按顺序合并到 dst 的零值字段中，dst 优先级最高；
+// 可通过 ask 标签（keep、override、append）调整字段合并策略
+func MergeInto[T any](dst *T, src ...T) {
+	rv := reflect.ValueOf(dst).Elem()
+	if rv.Kind() != reflect.Struct {
+		panic("ask: MergeInto requires a struct type, got " + rv.Type().String())
+	}
+	for i := range src {
+		mergeStruct(rv, reflect.ValueOf(&src[i]).Elem())
+	}
+}
+
+func mergeStruct(dst, src reflect.Value) {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		mergeField(dst.Field(i), src.Field(i), askTag(f))
+	}
+}
+
+func mergeField(dst, src reflect.Value, opts tagOptions) {
+	switch {
+	case opts.has("keep") || opts.has("-"):
+		return
+	case opts.has("override"):
+		if IsZero(dst.Interface()) {
+			dst.Set(src)
+		}
+		return
+	case opts.has("append"):
+		switch dst.Kind() {
+		case reflect.Slice:
+			if src.Len() > 0 {
+				merged := reflect.MakeSlice(dst.Type(), 0, dst.Len()+src.Len())
+				merged = reflect.AppendSlice(merged, dst)
+				dst.Set(reflect.AppendSlice(merged, src))
+			}
+			return
+		case reflect.Map:
+			if src.Len() > 0 {
+				merged := reflect.MakeMapWithSize(dst.Type(), dst.Len()+src.Len())
+				for _, m := range []reflect.Value{src, dst} {
+					iter := m.MapRange()
+					for iter.Next() {
+						merged.SetMapIndex(iter.Key(), iter.Value())
+					}
+				}
+				dst.Set(merged)
+			}
+			return
+		}
+	}
+
+	if dst.Kind() == reflect.Struct && !structs.IsLeaf(dst.Type()) {
+		mergeStruct(dst, src)
+		return
+	}
+	if IsZero(dst.Interface()) {
+		dst.Set(src)
+	}
+}
@@ -0,0 +1,135 @@
+package ask
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mergeDB struct {
+	DSN     string
+	MaxConn int
+}
+
+type mergeConfig struct {
+	Host     string
+	Port     int
+	Debug    bool
+	Started  time.Time
+	DB       mergeDB
+	Replica  *mergeDB
+	Tags     []string
+	Plugins  []string          `ask:"append"`
+	Labels   map[string]string `ask:"append"`
+	Version  string            `ask:"keep"`
+	LogLevel string            `ask:"override"`
+	Limits   mergeDB           `ask:"override"`
+	Internal string            `ask:"-"`
+	secret   string
+}
+
+func TestCoalesceStruct(t *testing.T) {
+	started := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	replica := &mergeDB{DSN: "replica"}
+
+	flags := mergeConfig{
+		Port:     9000,
+		Plugins:  []string{"auth"},
+		Labels:   map[string]string{"env": "flag"},
+		LogLevel: "debug",
+		secret:   "flag",
+	}
+	env := mergeConfig{
+		Host:     "env.local",
+		DB:       mergeDB{DSN: "postgres://env"},
+		Plugins:  []string{"metrics"},
+		Labels:   map[string]string{"env": "env", "team": "core"},
+		Version:  "2.0",
+		LogLevel: "warn",
+		Limits:   mergeDB{MaxConn: 5},
+	}
+	defaults := mergeConfig{
+		Host:     "localhost",
+		Port:     8080,
+		Started:  started,
+		DB:       mergeDB{DSN: "postgres://default", MaxConn: 10},
+		Replica:  replica,
+		Tags:     []string{"default"},
+		LogLevel: "info",
+		Limits:   mergeDB{DSN: "limits"},
+		Internal: "default",
+	}
+
+	got := CoalesceStruct(flags, env, defaults)
+	want := mergeConfig{
+		Host:     "env.local",
+		Port:     9000,
+		Started:  started,
+		DB:       mergeDB{DSN: "postgres://env", MaxConn: 10},
+		Replica:  replica,
+		Tags:     []string{"default"},
+		Plugins:  []string{"auth", "metrics"},
+		Labels:   map[string]string{"env": "flag", "team": "core"},
+		Version:  "",
+		LogLevel: "debug",
+		Limits:   mergeDB{MaxConn: 5},
+		secret:   "flag",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CoalesceStruct =\n%+v\nwant\n%+v", got, want)
+	}
+
+	// The layers are left untouched.
+	if len(flags.Plugins) != 1 || len(flags.Labels) != 1 || flags.Host != "" {
+		t.Errorf("CoalesceStruct modified its first layer: %+v", flags)
+	}
+}
+
+func TestCoalesceStructEmpty(t *testing.T) {
+	if got := CoalesceStruct[mergeConfig](); !reflect.DeepEqual(got, mergeConfig{}) {
+		t.Errorf("CoalesceStruct() = %+v; want zero", got)
+	}
+}
+
+func TestMergeInto(t *testing.T) {
+	dst := mergeConfig{Port: 3000, Tags: []string{}}
+	MergeInto(&dst, mergeConfig{Host: "a", Port: 1, Tags: []string{"x"}}, mergeConfig{Host: "b", Debug: true})
+
+	if dst.Host != "a" || dst.Port != 3000 || !dst.Debug {
+		t.Errorf("MergeInto = %+v; want Host a, Port 3000, Debug true", dst)
+	}
+	// An empty slice is zero under IsZero, so it is filled.
+	if len(dst.Tags) != 1 || dst.Tags[0] != "x" {
+		t.Errorf("MergeInto Tags = %v; want [x]", dst.Tags)
+	}
+}
+
+func TestMergeIntoRequiresStruct(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MergeInto(*int) did not panic")
+		}
+	}()
+	n := 0
+	MergeInto(&n, 1)
+}
+
+// textEndpoint is parsed from "host:port" as a whole, so it is a single value.
+type textEndpoint struct {
+	Host string
+	Port string
+}
+
+func (e *textEndpoint) UnmarshalText(b []byte) error {
+	e.Host, e.Port, _ = strings.Cut(string(b), ":")
+	return nil
+}
+
+func TestCoalesceStructTextLeaf(t *testing.T) {
+	type config struct{ API textEndpoint }
+	got := CoalesceStruct(config{API: textEndpoint{Host: "a"}}, config{API: textEndpoint{Host: "b", Port: "80"}})
+	if got.API != (textEndpoint{Host: "a"}) {
+		t.Errorf("CoalesceStruct(TextUnmarshaler) = %+v, want {a } taken as a whole", got.API)
+	}
+}
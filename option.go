@@ -0,0 +1,212 @@
+package ask
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// optionState is the state of an Option; the zero state is absent.
+type optionState uint8
+
+const (
+	optionAbsent optionState = iota
+	optionNull
+	optionSome
+)
+
+// Option holds a value that may be missing, for fields where the zero value is a
+// legitimate value and cannot stand for "not provided". It has three states:
+//
+//   - absent (None, the zero Option): no value was provided
+//   - null (Null): a value was provided as an explicit null
+//   - some (Some): a value, possibly a zero one, was provided
+//
+// Decoding JSON tells a missing key (absent) from "key": null (null). When encoding,
+// both absent and null Options are written as null; only absent Options report
+// IsZero, so on Go 1.24 and later, where encoding/json honors the omitzero option,
+// absent fields can be omitted instead. IsZero and Coalesce skip absent Options too.
+// Option implements sql.Scanner and driver.Valuer, mapping SQL NULL to null, so one
+// type carries optionality from the HTTP API to the database.
+//
+//	type UpdateUser struct {
+//		Avatar ask.Option[string] `json:"avatar,omitzero"`
+//	}
+//
+//	avatar := req.Avatar.OrElse("/default-avatar.png")
+//
+// Option 可选值，区分“未提供”“显式 null”和“有值（包括零值）”三种状态，
+// 支持 JSON 编解码与 database/sql
+type Option[T any] struct {
+	value T
+	state optionState
+}
+
+// Some returns an Option holding v, even if v is zero.
+//
+// Some 返回包含 v 的 Option（v 可以是零值）
+func Some[T any](v T) Option[T] {
+	return Option[T]{value: v, state: optionSome}
+}
+
+// None returns an absent Option, the same as the zero Option.
+//
+// None 返回未提供值的 Option
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// Null returns an Option that was provided as an explicit null.
+//
+// Null 返回显式为 null 的 Option
+func Null[T any]() Option[T] {
+	return Option[T]{state: optionNull}
+}
+
+// FromPtr returns Some(*p), or None if p is nil.
+//
+// FromPtr 由指针构造 Option，nil 对应 None
+func FromPtr[T any](p *T) Option[T] {
+	if p == nil {
+		return None[T]()
+	}
+	return Some(*p)
+}
+
+// FromOk returns Some(v) if ok, else None, adapting the comma-ok pattern:
+//
+//	name := ask.FromOk(os.LookupEnv("NAME"))
+//
+// FromOk 由 (值, ok) 形式构造 Option
+func FromOk[T any](v T, ok bool) Option[T] {
+	if !ok {
+		return None[T]()
+	}
+	return Some(v)
+}
+
+// Get returns the value and true if o holds one.
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.state == optionSome
+}
+
+// IsSome reports whether o holds a value.
+func (o Option[T]) IsSome() bool {
+	return o.state == optionSome
+}
+
+// IsNull reports whether o was provided as an explicit null.
+func (o Option[T]) IsNull() bool {
+	return o.state == optionNull
+}
+
+// IsZero reports whether o is absent. Null and Some(zero) are not zero.
+func (o Option[T]) IsZero() bool {
+	return o.state == optionAbsent
+}
+
+// OrElse returns the value of o, or def if o holds none.
+//
+// OrElse 有值时返回该值，否则返回 def
+func (o Option[T]) OrElse(def T) T {
+	if o.state == optionSome {
+		return o.value
+	}
+	return def
+}
+
+// OrElseFunc returns the value of o, or the result of fn if o holds none.
+// fn is only called when needed.
+//
+// OrElseFunc 有值时返回该值，否则调用 fn
+func (o Option[T]) OrElseFunc(fn func() T) T {
+	if o.state == optionSome {
+		return o.value
+	}
+	return fn()
+}
+
+// Ptr returns a pointer to a copy of the value, or nil if o holds none.
+func (o Option[T]) Ptr() *T {
+	if o.state != optionSome {
+		return nil
+	}
+	v := o.value
+	return &v
+}
+
+// String formats o as Some(v), Null or None.
+func (o Option[T]) String() string {
+	switch o.state {
+	case optionSome:
+		return fmt.Sprintf("Some(%v)", o.value)
+	case optionNull:
+		return "Null"
+	}
+	return "None"
+}
+
+// MapOption applies fn to the value of o. Absent and null Options are passed through.
+//
+// MapOption 对 Option 中的值应用 fn，None 与 Null 原样保留
+func MapOption[T, U any](o Option[T], fn func(T) U) Option[U] {
+	if o.state != optionSome {
+		return Option[U]{state: o.state}
+	}
+	return Some(fn(o.value))
+}
+
+// FlatMapOption applies fn, which itself returns an Option, to the value of o.
+// Absent and null Options are passed through.
+//
+// FlatMapOption 对 Option 中的值应用返回 Option 的 fn
+func FlatMapOption[T, U any](o Option[T], fn func(T) Option[U]) Option[U] {
+	if o.state != optionSome {
+		return Option[U]{state: o.state}
+	}
+	return fn(o.value)
+}
+
+// MarshalJSON encodes the value, or null if o holds none.
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+	if o.state != optionSome {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes null as Null and anything else as Some.
+// A key missing from the document leaves the Option absent.
+func (o *Option[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Null[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
+
+// Scan implements sql.Scanner: SQL NULL becomes Null, anything else Some.
+func (o *Option[T]) Scan(src any) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	if !n.Valid {
+		*o = Null[T]()
+		return nil
+	}
+	*o = Some(n.V)
+	return nil
+}
+
+// Value implements driver.Valuer: an Option holding no value is stored as SQL NULL.
+func (o Option[T]) Value() (driver.Value, error) {
+	return sql.Null[T]{V: o.value, Valid: o.state == optionSome}.Value()
+}
@@ -0,0 +1,35 @@
+//go:build go1.24
+
+package ask
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// encoding/json honors the omitzero option from Go 1.24 on, so absent Options can be
+// left out while explicit nulls are kept.
+func TestOptionJSONOmitZero(t *testing.T) {
+	type patch struct {
+		Name   Option[string] `json:"name,omitzero"`
+		Age    Option[int]    `json:"age,omitzero"`
+		Avatar Option[string] `json:"avatar,omitzero"`
+	}
+
+	var p patch
+	if err := json.Unmarshal([]byte(`{"name": "", "age": null}`), &p); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"name":"","age":null}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var back patch
+	if err := json.Unmarshal(data, &back); err != nil || back != p {
+		t.Errorf("round trip = %+v, %v; want %+v", back, err, p)
+	}
+}
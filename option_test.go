@@ -0,0 +1,170 @@
+package ask
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestOption(t *testing.T) {
+	some := Some(0)
+	none := None[int]()
+	null := Null[int]()
+
+	if v, ok := some.Get(); !ok || v != 0 || !some.IsSome() || some.IsNull() || some.IsZero() {
+		t.Errorf("Some(0) = %v, %v; IsSome %v IsNull %v IsZero %v", v, ok, some.IsSome(), some.IsNull(), some.IsZero())
+	}
+	if _, ok := none.Get(); ok || none.IsSome() || none.IsNull() || !none.IsZero() {
+		t.Errorf("None() state wrong: %v", none)
+	}
+	if _, ok := null.Get(); ok || null.IsSome() || !null.IsNull() || null.IsZero() {
+		t.Errorf("Null() state wrong: %v", null)
+	}
+	if (Option[int]{}) != none {
+		t.Error("zero Option != None()")
+	}
+
+	if some.OrElse(5) != 0 || none.OrElse(5) != 5 || null.OrElse(5) != 5 {
+		t.Errorf("OrElse() = %d, %d, %d", some.OrElse(5), none.OrElse(5), null.OrElse(5))
+	}
+	called := false
+	if got := some.OrElseFunc(func() int { called = true; return 5 }); got != 0 || called {
+		t.Errorf("Some.OrElseFunc() = %d, called %v; want 0 without calling fn", got, called)
+	}
+	if got := null.OrElseFunc(func() int { return 5 }); got != 5 {
+		t.Errorf("Null.OrElseFunc() = %d, want 5", got)
+	}
+
+	if p := some.Ptr(); p == nil || *p != 0 {
+		t.Errorf("Some.Ptr() = %v", p)
+	}
+	if none.Ptr() != nil || null.Ptr() != nil {
+		t.Error("Ptr() of None/Null != nil")
+	}
+
+	for o, want := range map[Option[int]]string{some: "Some(0)", none: "None", null: "Null"} {
+		if o.String() != want {
+			t.Errorf("String() = %q, want %q", o.String(), want)
+		}
+	}
+}
+
+func TestOptionConstructors(t *testing.T) {
+	n := 7
+	if got := FromPtr(&n); got != Some(7) {
+		t.Errorf("FromPtr(&7) = %v", got)
+	}
+	if got := FromPtr[int](nil); got != None[int]() {
+		t.Errorf("FromPtr(nil) = %v", got)
+	}
+
+	m := map[string]int{"zero": 0}
+	v, ok := m["zero"]
+	if got := FromOk(v, ok); got != Some(0) {
+		t.Errorf("FromOk(present zero) = %v, want Some(0)", got)
+	}
+	v, ok = m["missing"]
+	if got := FromOk(v, ok); got != None[int]() {
+		t.Errorf("FromOk(missing) = %v, want None", got)
+	}
+}
+
+func TestMapOption(t *testing.T) {
+	if got := MapOption(Some(42), strconv.Itoa); got != Some("42") {
+		t.Errorf("MapOption(Some) = %v", got)
+	}
+	if got := MapOption(None[int](), strconv.Itoa); got != None[string]() {
+		t.Errorf("MapOption(None) = %v", got)
+	}
+	if got := MapOption(Null[int](), strconv.Itoa); got != Null[string]() {
+		t.Errorf("MapOption(Null) = %v", got)
+	}
+
+	parse := func(s string) Option[int] {
+		n, err := strconv.Atoi(s)
+		return FromOk(n, err == nil)
+	}
+	if got := FlatMapOption(Some("12"), parse); got != Some(12) {
+		t.Errorf("FlatMapOption(Some(12)) = %v", got)
+	}
+	if got := FlatMapOption(Some("x"), parse); got != None[int]() {
+		t.Errorf("FlatMapOption(Some(x)) = %v", got)
+	}
+	if got := FlatMapOption(Null[string](), parse); got != Null[int]() {
+		t.Errorf("FlatMapOption(Null) = %v", got)
+	}
+}
+
+func TestOptionCoalesce(t *testing.T) {
+	if got := Coalesce(None[int](), Some(0), Some(5)); got != Some(0) {
+		t.Errorf("Coalesce(None, Some(0), Some(5)) = %v, want Some(0)", got)
+	}
+	if !IsZero(None[string]()) || IsZero(Some("")) || IsZero(Null[string]()) {
+		t.Error("IsZero does not follow Option.IsZero")
+	}
+}
+
+// optionPatch avoids the omitzero tag option, which encoding/json ignores before Go 1.24;
+// TestOptionJSONOmitZero covers it on newer releases
+type optionPatch struct {
+	Name    Option[string]        `json:"name"`
+	Age     Option[int]           `json:"age"`
+	Avatar  Option[string]        `json:"avatar"`
+	Timeout Option[time.Duration] `json:"timeout"`
+}
+
+func TestOptionJSON(t *testing.T) {
+	var p optionPatch
+	if err := json.Unmarshal([]byte(`{"name": "", "age": null}`), &p); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if p.Name != Some("") {
+		t.Errorf("Name = %v, want Some(\"\")", p.Name)
+	}
+	if p.Age != Null[int]() {
+		t.Errorf("Age = %v, want Null", p.Age)
+	}
+	if p.Avatar != None[string]() {
+		t.Errorf("Avatar = %v, want None", p.Avatar)
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"name":"","age":null,"avatar":null,"timeout":null}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"age": "old"}`), &p); err == nil {
+		t.Error("Unmarshal(type mismatch) error = nil, want error")
+	}
+}
+
+func TestOptionSQL(t *testing.T) {
+	var o Option[string]
+	if err := o.Scan("alice"); err != nil || o != Some("alice") {
+		t.Errorf("Scan(alice) = %v, %v", o, err)
+	}
+	if err := o.Scan(nil); err != nil || o != Null[string]() {
+		t.Errorf("Scan(nil) = %v, %v; want Null", o, err)
+	}
+
+	var n Option[int64]
+	if err := n.Scan(int64(0)); err != nil || n != Some(int64(0)) {
+		t.Errorf("Scan(0) = %v, %v; want Some(0)", n, err)
+	}
+	if err := n.Scan("x"); err == nil {
+		t.Error("Scan(x) into Option[int64] error = nil, want error")
+	}
+
+	if v, err := Some("bob").Value(); err != nil || v != "bob" {
+		t.Errorf("Some.Value() = %v, %v", v, err)
+	}
+	for _, o := range []Option[string]{None[string](), Null[string]()} {
+		if v, err := o.Value(); err != nil || v != nil {
+			t.Errorf("%v.Value() = %v, %v; want nil", o, v, err)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package ask
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+	urlType             = reflect.TypeOf(url.URL{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	presetterType       = reflect.TypeOf((*presetter)(nil)).Elem()
+)
+
+// presetter is implemented by *Flag[T], which stores parsed values without
+// marking itself as set.
+type presetter interface {
+	setDefault(s string) error
+}
+
+// ParseInto parses s according to the type ptr points to and stores the result in *ptr.
+// It uses the same rules as default tags, see ApplyDefaults:
+//
+//	var timeout time.Duration
+//	err := ask.ParseInto(&timeout, "30s")
+//
+// ParseInto 按 ptr 指向的类型解析字符串并写入，规则与 default 标签一致
+func ParseInto(ptr any, s string) error {
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("ask: ParseInto requires a non-nil pointer, got %T", ptr)
+	}
+	return setString(rv.Elem(), s)
+}
+
+// setString parses s according to the type of rv and stores the result in rv,
+// which must be settable. It understands:
+//   - strings, bools, ints, uints and floats (via strconv); integers are decimal, so
+//     "010" is 10, unless they carry an explicit 0x, 0o or 0b prefix
+//   - time.Duration (time.ParseDuration) and time.Time (RFC 3339)
+//   - url.URL (url.Parse)
+//   - types implementing encoding.TextUnmarshaler
+//   - Flag[T], which holds the value without being marked as set
+//   - slices as comma-separated elements: "a,b,c"
+//   - maps as comma-separated key=value pairs: "a=1,b=2"
+//   - pointers to any of the above, which are allocated as needed
+//
+// setString 按 rv 的类型解析字符串并写入 rv
+func setString(rv reflect.Value, s string) error {
+	t := rv.Type()
+
+	switch t {
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(d))
+		return nil
+	case timeType:
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(tm))
+		return nil
+	case urlType:
+		u, err := url.Parse(s)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(*u))
+		return nil
+	}
+
+	if t.Kind() != reflect.Ptr && reflect.PointerTo(t).Implements(presetterType) {
+		return rv.Addr().Interface().(presetter).setDefault(s)
+	}
+	if t.Kind() != reflect.Ptr && reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, intBase(s), t.Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, intBase(s), t.Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	case reflect.Ptr:
+		p := reflect.New(t.Elem())
+		if err := setString(p.Elem(), s); err != nil {
+			return err
+		}
+		rv.Set(p)
+	case reflect.Slice:
+		parts := splitList(s)
+		slice := reflect.MakeSlice(t, len(parts), len(parts))
+		for i, part := range parts {
+			if err := setString(slice.Index(i), part); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		rv.Set(slice)
+	case reflect.Map:
+		parts := splitList(s)
+		m := reflect.MakeMapWithSize(t, len(parts))
+		for _, part := range parts {
+			k, v, ok := strings.Cut(part, "=")
+			if !ok {
+				return fmt.Errorf("map entry %q: missing '='", part)
+			}
+			key := reflect.New(t.Key()).Elem()
+			if err := setString(key, strings.TrimSpace(k)); err != nil {
+				return fmt.Errorf("map key %q: %w", k, err)
+			}
+			val := reflect.New(t.Elem()).Elem()
+			if err := setString(val, strings.TrimSpace(v)); err != nil {
+				return fmt.Errorf("map value for %q: %w", k, err)
+			}
+			m.SetMapIndex(key, val)
+		}
+		rv.Set(m)
+	default:
+		return fmt.Errorf("unsupported type %v", t)
+	}
+	return nil
+}
+
+// splitList splits a comma-separated list, trimming spaces. An empty string is an empty list.
+func splitList(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+// intBase returns the base to parse the integer s in: 0, letting strconv read the
+// prefix, when s starts with 0x, 0o or 0b, and 10 otherwise, so that leading zeros
+// as in "08080" do not switch to octal.
+func intBase(s string) int {
+	s = strings.TrimLeft(s, "+-")
+	if len(s) > 2 && s[0] == '0' {
+		switch s[1] {
+		case 'x', 'X', 'o', 'O', 'b', 'B':
+			return 0
+		}
+	}
+	return 10
+}
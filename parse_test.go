@@ -0,0 +1,62 @@
+package ask
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseInto(t *testing.T) {
+	var d time.Duration
+	if err := ParseInto(&d, "1m30s"); err != nil || d != 90*time.Second {
+		t.Errorf("ParseInto(duration) = %v, %v; want 1m30s", d, err)
+	}
+
+	var n uint16
+	if err := ParseInto(&n, "0x10"); err != nil || n != 16 {
+		t.Errorf("ParseInto(uint16, 0x10) = %v, %v; want 16", n, err)
+	}
+	// Leading zeros are decimal; only an explicit prefix changes the base
+	for s, want := range map[string]uint16{"010": 10, "08080": 8080, "0o17": 15, "0b101": 5} {
+		if err := ParseInto(&n, s); err != nil || n != want {
+			t.Errorf("ParseInto(uint16, %s) = %v, %v; want %d", s, n, err, want)
+		}
+	}
+	var i int
+	if err := ParseInto(&i, "-010"); err != nil || i != -10 {
+		t.Errorf("ParseInto(int, -010) = %v, %v; want -10", i, err)
+	}
+	if err := ParseInto(&n, "70000"); err == nil {
+		t.Errorf("ParseInto(uint16, 70000) error = nil; want overflow")
+	}
+
+	var u url.URL
+	if err := ParseInto(&u, "https://example.com/api"); err != nil || u.Host != "example.com" || u.Path != "/api" {
+		t.Errorf("ParseInto(url.URL) = %v, %v", u.String(), err)
+	}
+	if err := ParseInto(&u, "http://[::1"); err == nil {
+		t.Errorf("ParseInto(url.URL, bad) error = nil; want error")
+	}
+
+	var ids []int
+	if err := ParseInto(&ids, ""); err != nil || len(ids) != 0 {
+		t.Errorf("ParseInto([]int, '') = %v, %v; want empty", ids, err)
+	}
+
+	var m map[string]bool
+	if err := ParseInto(&m, "a=true,b=false"); err != nil || !reflect.DeepEqual(m, map[string]bool{"a": true, "b": false}) {
+		t.Errorf("ParseInto(map) = %v, %v", m, err)
+	}
+	if err := ParseInto(&m, "a"); err == nil {
+		t.Errorf("ParseInto(map, a) error = nil; want missing '='")
+	}
+
+	var ch chan int
+	if err := ParseInto(&ch, "1"); err == nil {
+		t.Errorf("ParseInto(chan) error = nil; want unsupported type")
+	}
+	if err := ParseInto(n, "1"); err == nil {
+		t.Errorf("ParseInto(non-pointer) error = nil; want error")
+	}
+}
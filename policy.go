@@ -0,0 +1,105 @@
+package ask
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// Policy selects the rule used to decide whether a value counts as zero.
+// The plain functions (IsZero, If, Ifelse, Coalesce) always use EmptyAsZero;
+// the *With variants let callers pick the rule explicitly.
+//
+// Policy 零值判断策略，普通函数固定使用 EmptyAsZero，*With 系列函数可显式指定策略
+type Policy uint8
+
+const (
+	// EmptyAsZero is the package default: Go zero values are zero,
+	// and so are empty (but non-nil) slices and maps.
+	// EmptyAsZero 默认策略：零值以及长度为 0 的切片、映射都视为零值
+	EmptyAsZero Policy = iota
+
+	// Strict follows reflect.Value.IsZero: only the language zero value counts,
+	// so an empty non-nil slice or map is non-zero and IsZero methods are not consulted.
+	// Strict 严格策略：仅语言层面的零值视为零值，空但非 nil 的切片、映射不是零值
+	Strict
+
+	// NilOnly treats only nil interfaces, pointers, slices, maps, channels and funcs as zero.
+	// Values such as 0, "" and false are kept.
+	// NilOnly 仅 nil 视为零值，0、""、false 均视为有效值
+	NilOnly
+
+	// Empty applies IsEmpty: containers and collection types (Len, Empty, IsEmpty methods)
+	// are zero when they hold nothing, and everything else falls back to IsZero.
+	// Empty 空值策略：使用 IsEmpty 判断，集合类型为空即视为零值
+	Empty
+)
+
+// String returns the name of the policy.
+func (p Policy) String() string {
+	switch p {
+	case EmptyAsZero:
+		return "EmptyAsZero"
+	case Strict:
+		return "Strict"
+	case NilOnly:
+		return "NilOnly"
+	case Empty:
+		return "Empty"
+	default:
+		return "Policy(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+// IsZeroWith checks if a value is zero under the given policy.
+// IsZeroWith(v, EmptyAsZero) is equivalent to IsZero(v).
+// Unknown policies fall back to EmptyAsZero.
+//
+// IsZeroWith 按指定策略判断值是否为零值
+func IsZeroWith(v any, p Policy) bool {
+	switch p {
+	case Strict:
+		if v == nil {
+			return true
+		}
+		return reflect.ValueOf(v).IsZero()
+	case NilOnly:
+		return IsNil(v)
+	case Empty:
+		return IsEmpty(v)
+	default:
+		return IsZero(v)
+	}
+}
+
+// IfWith is the policy-aware variant of If.
+// Bool and error conditions behave exactly as in If; any other condition
+// is checked with IsZeroWith under the given policy.
+//
+// IfWith 按指定策略判断条件的三目运算符，bool 与 error 条件的行为与 If 一致
+func IfWith[T any, C any](p Policy, condition C, trueVal, falseVal T) T {
+	switch c := any(condition).(type) {
+	case bool:
+		return If(c, trueVal, falseVal)
+	case error:
+		return If(c, trueVal, falseVal)
+	}
+
+	if !IsZeroWith(condition, p) {
+		return trueVal
+	}
+	return falseVal
+}
+
+// CoalesceWith is the policy-aware variant of Coalesce.
+// It returns the first value that is non-zero under the given policy.
+//
+// CoalesceWith 按指定策略返回第一个非零值
+func CoalesceWith[T any](p Policy, values ...T) T {
+	for _, v := range values {
+		if !IsZeroWith(v, p) {
+			return v
+		}
+	}
+	var zero T
+	return zero
+}
@@ -0,0 +1,111 @@
+package ask
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsZeroWith(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  any
+		policy Policy
+		expect bool
+	}{
+		{"default nil", nil, EmptyAsZero, true},
+		{"default empty slice", []int{}, EmptyAsZero, true},
+		{"default empty map", map[string]int{}, EmptyAsZero, true},
+		{"default zero array", [3]int{}, EmptyAsZero, true},
+		{"default zero int", 0, EmptyAsZero, true},
+
+		{"strict nil", nil, Strict, true},
+		{"strict nil slice", []int(nil), Strict, true},
+		{"strict empty slice", []int{}, Strict, false},
+		{"strict empty map", map[string]int{}, Strict, false},
+		{"strict zero array", [3]int{}, Strict, true},
+		{"strict zero int", 0, Strict, true},
+		{"strict empty string", "", Strict, true},
+		{"strict ignores IsZero method", decimal{scale: 2}, Strict, false},
+
+		{"nil-only nil", nil, NilOnly, true},
+		{"nil-only nil pointer", (*int)(nil), NilOnly, true},
+		{"nil-only nil slice", []int(nil), NilOnly, true},
+		{"nil-only nil map", map[string]int(nil), NilOnly, true},
+		{"nil-only empty slice", []int{}, NilOnly, false},
+		{"nil-only zero int", 0, NilOnly, false},
+		{"nil-only empty string", "", NilOnly, false},
+		{"nil-only false", false, NilOnly, false},
+
+		{"empty zero array", [3]int{}, Empty, false},
+		{"empty empty slice", []int{}, Empty, true},
+		{"empty zero int", 0, Empty, true},
+
+		{"unknown policy", []int{}, Policy(42), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsZeroWith(tt.value, tt.policy); got != tt.expect {
+				t.Errorf("IsZeroWith(%v, %v) = %v; want %v", tt.value, tt.policy, got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestIfWith(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		cond   any
+		expect string
+	}{
+		{"bool ignores policy", NilOnly, false, "no"},
+		{"error ignores policy", Strict, errors.New("error"), "yes"},
+		{"nil error ignores policy", NilOnly, error(nil), "no"},
+		{"default empty slice", EmptyAsZero, []int{}, "no"},
+		{"strict empty slice", Strict, []int{}, "yes"},
+		{"nil-only zero int", NilOnly, 0, "yes"},
+		{"nil-only nil pointer", NilOnly, (*int)(nil), "no"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IfWith(tt.policy, tt.cond, "yes", "no"); got != tt.expect {
+				t.Errorf("IfWith(%v, %v) = %v; want %v", tt.policy, tt.cond, got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestCoalesceWith(t *testing.T) {
+	empty := []int{}
+	if got := CoalesceWith(EmptyAsZero, nil, empty, []int{1}); len(got) != 1 {
+		t.Errorf("CoalesceWith(EmptyAsZero) = %v; want [1]", got)
+	}
+	if got := CoalesceWith(Strict, nil, empty, []int{1}); got == nil || len(got) != 0 {
+		t.Errorf("CoalesceWith(Strict) = %v; want []", got)
+	}
+	if got := CoalesceWith(NilOnly, (*int)(nil), new(int)); got == nil {
+		t.Errorf("CoalesceWith(NilOnly) = nil; want non-nil pointer")
+	}
+	if got := CoalesceWith(NilOnly, 0, 42); got != 0 {
+		t.Errorf("CoalesceWith(NilOnly, 0, 42) = %v; want 0", got)
+	}
+	if got := CoalesceWith[string](Strict); got != "" {
+		t.Errorf("CoalesceWith(Strict) = %q; want empty", got)
+	}
+}
+
+func TestPolicyString(t *testing.T) {
+	for p, want := range map[Policy]string{
+		EmptyAsZero: "EmptyAsZero",
+		Strict:      "Strict",
+		NilOnly:     "NilOnly",
+		Empty:       "Empty",
+		Policy(9):   "Policy(9)",
+	} {
+		if got := p.String(); got != want {
+			t.Errorf("Policy.String() = %q; want %q", got, want)
+		}
+	}
+}
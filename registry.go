@@ -0,0 +1,116 @@
+package ask
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// RegisterZeroFunc registers fn as the zero check for values of type T.
+// IsZero, If, Ifelse, Coalesce and IsDeepZero consult registered funcs before any
+// built-in rule, which lets types whose "unset" value is not the Go zero value
+// (a nil UUID, a NaN decimal, a protobuf wrapper) take part in conditionals.
+//
+// T must be a concrete type; registering an interface type panics. Registering the
+// same type again replaces the previous func. The returned function restores the
+// registration that was in place before this call, so tests can scope it:
+//
+//	t.Cleanup(ask.RegisterZeroFunc(func(id uuid.UUID) bool { return id == uuid.Nil }))
+//
+// The registry is safe for concurrent use.
+//
+// RegisterZeroFunc 为类型 T 注册自定义零值判断函数，优先于内置规则；
+// 返回的函数用于恢复注册前的状态，便于在测试中限定作用域
+func RegisterZeroFunc[T any](fn func(T) bool) (restore func()) {
+	return zeroFuncs.register(keyOf[T](), func(v any) bool { return fn(v.(T)) })
+}
+
+// RegisterEmptyFunc registers fn as the emptiness check for values of type T.
+// IsEmpty consults registered funcs before any built-in rule.
+// It follows the same rules as RegisterZeroFunc.
+//
+// RegisterEmptyFunc 为类型 T 注册自定义空值判断函数，优先于 IsEmpty 的内置规则
+func RegisterEmptyFunc[T any](fn func(T) bool) (restore func()) {
+	return emptyFuncs.register(keyOf[T](), func(v any) bool { return fn(v.(T)) })
+}
+
+// ResetRegistry removes every func registered with RegisterZeroFunc and RegisterEmptyFunc.
+//
+// ResetRegistry 清空所有已注册的零值和空值判断函数
+func ResetRegistry() {
+	zeroFuncs.reset()
+	emptyFuncs.reset()
+}
+
+var (
+	zeroFuncs  = &registry{}
+	emptyFuncs = &registry{}
+)
+
+// registry maps interface type words to user-supplied checkers.
+// Like checkerCache, reads are a lock-free load of an immutable map. The map pointer
+// is nil while nothing is registered, which keeps the lookup nearly free.
+type registry struct {
+	mu sync.Mutex
+	m  atomic.Pointer[map[unsafe.Pointer]checker]
+}
+
+// lookup returns the checker registered for the dynamic type of v, or nil.
+func (r *registry) lookup(v any) checker {
+	m := r.m.Load()
+	if m == nil {
+		return nil
+	}
+	return (*m)[typeOf(v)]
+}
+
+func (r *registry) register(key unsafe.Pointer, fn checker) (restore func()) {
+	prev, had := r.swap(key, fn, true)
+	var once sync.Once
+	return func() {
+		once.Do(func() { r.swap(key, prev, had) })
+	}
+}
+
+// swap sets (or, when set is false, deletes) the checker for key and returns the previous one.
+func (r *registry) swap(key unsafe.Pointer, fn checker, set bool) (checker, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m := make(map[unsafe.Pointer]checker)
+	if old := r.m.Load(); old != nil {
+		for k, v := range *old {
+			m[k] = v
+		}
+	}
+	prev, had := m[key]
+	if set {
+		m[key] = fn
+	} else {
+		delete(m, key)
+	}
+
+	if len(m) == 0 {
+		r.m.Store(nil)
+	} else {
+		r.m.Store(&m)
+	}
+	return prev, had
+}
+
+func (r *registry) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.m.Store(nil)
+}
+
+// keyOf returns the interface type word of T.
+func keyOf[T any]() unsafe.Pointer {
+	if t := reflect.TypeFor[T](); t.Kind() == reflect.Interface {
+		panic(fmt.Sprintf("ask: cannot register interface type %v", t))
+	}
+	var zero T
+	return typeOf(zero)
+}
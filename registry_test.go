@@ -0,0 +1,123 @@
+package ask
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+// uuid mimics UUID libraries: the nil UUID is the all-zero array.
+type uuid [16]byte
+
+var nilUUID uuid
+
+// money mimics decimal types where NaN means "unset".
+type money struct {
+	amount float64
+}
+
+func TestRegisterZeroFunc(t *testing.T) {
+	t.Cleanup(RegisterZeroFunc(func(m money) bool { return math.IsNaN(m.amount) }))
+
+	unset := money{amount: math.NaN()}
+	free := money{}
+
+	if !IsZero(unset) {
+		t.Errorf("IsZero(NaN money) = false; want true")
+	}
+	if IsZero(free) {
+		t.Errorf("IsZero(0 money) = true; want false (registered func overrides)")
+	}
+	if got := If(unset, "set", "unset"); got != "unset" {
+		t.Errorf("If(NaN money) = %v; want unset", got)
+	}
+	if got := Ifelse(unset, money{amount: 1}); got.amount != 1 {
+		t.Errorf("Ifelse(NaN money) = %v; want default", got)
+	}
+	if got := Coalesce(unset, free, money{amount: 2}); got.amount != 0 || math.IsNaN(got.amount) {
+		t.Errorf("Coalesce(NaN, 0, 2) = %v; want 0", got)
+	}
+	if !IsDeepZero(struct{ Price money }{unset}) {
+		t.Errorf("IsDeepZero(struct with NaN money) = false; want true")
+	}
+}
+
+func TestRegisterZeroFuncRestore(t *testing.T) {
+	id := uuid{1}
+	if IsZero(id) {
+		t.Fatalf("IsZero(uuid{1}) = true before registration")
+	}
+
+	restoreOuter := RegisterZeroFunc(func(u uuid) bool { return u == nilUUID || u == uuid{1} })
+	if !IsZero(id) {
+		t.Errorf("IsZero(uuid{1}) = false; want true with outer registration")
+	}
+
+	restoreInner := RegisterZeroFunc(func(u uuid) bool { return false })
+	if IsZero(nilUUID) {
+		t.Errorf("IsZero(nil uuid) = true; want false with inner registration")
+	}
+
+	restoreInner()
+	restoreInner() // restoring twice is a no-op
+	if !IsZero(id) {
+		t.Errorf("IsZero(uuid{1}) = false; want outer registration restored")
+	}
+
+	restoreOuter()
+	if IsZero(id) || !IsZero(nilUUID) {
+		t.Errorf("built-in rules not restored after unregistering")
+	}
+}
+
+func TestRegisterEmptyFunc(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+	RegisterEmptyFunc(func(u uuid) bool { return u == nilUUID })
+
+	if !IsEmpty(nilUUID) {
+		t.Errorf("IsEmpty(nil uuid) = false; want true")
+	}
+	if IsEmpty(uuid{1}) {
+		t.Errorf("IsEmpty(uuid{1}) = true; want false")
+	}
+	if !IsZeroWith(nilUUID, Empty) {
+		t.Errorf("IsZeroWith(nil uuid, Empty) = false; want true")
+	}
+
+	ResetRegistry()
+	if IsEmpty(nilUUID) {
+		t.Errorf("IsEmpty([16]byte) = true after reset; want false (array of length 16)")
+	}
+}
+
+func TestRegisterInterfacePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("RegisterZeroFunc[error] did not panic")
+		}
+	}()
+	RegisterZeroFunc(func(error) bool { return true })
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	t.Cleanup(ResetRegistry)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				RegisterZeroFunc(func(m money) bool { return m.amount < 0 })()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				_ = IsZero(money{amount: -1})
+				_ = Coalesce(money{}, money{amount: 1})
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,196 @@
+package ask
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Result holds either a value (Ok) or the error that prevented computing it, so the
+// (T, error) pair returned by most Go functions can be passed around as one value:
+//
+//	port := ask.Try(strconv.Atoi(s)).UnwrapOr(8080)
+//
+// A Result is not itself an error; use Err, Is or As to inspect the failure. IsZero
+// reports whether the Result failed, so If treats an Ok Result as true. To take the
+// first Ok Result of several, use CoalesceResult, which keeps the last error when
+// all of them fail; Coalesce would return the zero Result instead.
+//
+// The zero Result was never built by Try, Ok or Err: it counts as failed, with
+// Err returning ErrEmptyResult, so it is never mistaken for a success.
+//
+// Result 结果类型，封装 (T, error)；Ok 视为真值，零值 Result 视为失败
+type Result[T any] struct {
+	value T
+	err   error
+	ok    bool
+}
+
+// ErrEmptyResult is the error of the zero Result, which holds neither a value nor an error.
+var ErrEmptyResult = errors.New("empty Result")
+
+// Try wraps the results of a call returning (T, error):
+//
+//	r := ask.Try(os.ReadFile(path))
+//
+// As in If, a typed nil such as (*MyErr)(nil) stored in err counts as nil.
+//
+// Try 由 (值, error) 构造 Result
+func Try[T any](v T, err error) Result[T] {
+	if err != nil && IsNil(err) {
+		err = nil
+	}
+	if err != nil {
+		return Result[T]{err: err}
+	}
+	return Result[T]{value: v, ok: true}
+}
+
+// Ok returns a successful Result holding v.
+//
+// Ok 返回成功的 Result
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v, ok: true}
+}
+
+// Err returns a failed Result holding err. Err(nil), like a typed nil error, returns
+// the zero Result, which fails with ErrEmptyResult.
+//
+// Err 返回失败的 Result
+func Err[T any](err error) Result[T] {
+	if err == nil || IsNil(err) {
+		return Result[T]{}
+	}
+	return Result[T]{err: err}
+}
+
+// Get returns the value and the error, undoing Try.
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.Err()
+}
+
+// IsOk reports whether r holds a value.
+func (r Result[T]) IsOk() bool {
+	return r.ok
+}
+
+// IsErr reports whether r failed, including the zero Result.
+func (r Result[T]) IsErr() bool {
+	return !r.ok
+}
+
+// IsZero reports whether r failed, so that If, IsZero and Coalesce treat Ok Results as set.
+func (r Result[T]) IsZero() bool {
+	return !r.ok
+}
+
+// Err returns the error of r, ErrEmptyResult for the zero Result, or nil if r is Ok.
+func (r Result[T]) Err() error {
+	if !r.ok && r.err == nil {
+		return ErrEmptyResult
+	}
+	return r.err
+}
+
+// Is reports whether the error of r matches target, as errors.Is does.
+func (r Result[T]) Is(target error) bool {
+	return errors.Is(r.Err(), target)
+}
+
+// As finds the first error in the chain of r that matches target, as errors.As does.
+func (r Result[T]) As(target any) bool {
+	return !r.ok && errors.As(r.Err(), target)
+}
+
+// Unwrap returns the value of r. It panics if r holds an error; the panic value wraps
+// that error, so a recover can still inspect it with errors.Is.
+//
+// Unwrap 返回值，失败时 panic
+func (r Result[T]) Unwrap() T {
+	if !r.ok {
+		panic(fmt.Errorf("ask: Unwrap of failed Result: %w", r.Err()))
+	}
+	return r.value
+}
+
+// UnwrapOr returns the value of r, or def if r holds an error.
+//
+// UnwrapOr 成功时返回值，否则返回 def
+func (r Result[T]) UnwrapOr(def T) T {
+	if !r.ok {
+		return def
+	}
+	return r.value
+}
+
+// UnwrapOrElse returns the value of r, or the result of fn applied to its error.
+// fn is only called when r holds an error.
+//
+// UnwrapOrElse 成功时返回值，否则以错误调用 fn
+func (r Result[T]) UnwrapOrElse(fn func(error) T) T {
+	if !r.ok {
+		return fn(r.Err())
+	}
+	return r.value
+}
+
+// Match calls okFn with the value of r, or errFn with its error.
+//
+// Match 根据成功或失败调用对应的函数
+func (r Result[T]) Match(okFn func(T), errFn func(error)) {
+	if !r.ok {
+		errFn(r.Err())
+		return
+	}
+	okFn(r.value)
+}
+
+// String formats r as Ok(v) or Err(msg).
+func (r Result[T]) String() string {
+	if !r.ok {
+		return fmt.Sprintf("Err(%v)", r.Err())
+	}
+	return fmt.Sprintf("Ok(%v)", r.value)
+}
+
+// MapResult applies fn to the value of r. A failed Result is passed through.
+//
+// MapResult 对成功的 Result 中的值应用 fn，失败时原样传递错误
+func MapResult[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if !r.ok {
+		return Result[U]{err: r.err}
+	}
+	return Ok(fn(r.value))
+}
+
+// AndThen applies fn, which itself may fail, to the value of r, chaining fallible steps:
+//
+//	cfg := ask.AndThen(ask.Try(os.ReadFile(path)), parseConfig)
+//
+// A failed Result is passed through without calling fn.
+//
+// AndThen 对成功的 Result 继续执行可能失败的 fn
+func AndThen[T, U any](r Result[T], fn func(T) Result[U]) Result[U] {
+	if !r.ok {
+		return Result[U]{err: r.err}
+	}
+	return fn(r.value)
+}
+
+// CoalesceResult returns the first Ok Result. If every Result failed, it returns the
+// last one, so the error that ended the chain is kept:
+//
+//	cfg := ask.CoalesceResult(loadFile(path), loadEnv(), ask.Ok(defaults))
+//
+// CoalesceResult() with no Results returns the zero Result.
+//
+// CoalesceResult 返回第一个成功的 Result，全部失败时返回最后一个（保留其错误）
+func CoalesceResult[T any](results ...Result[T]) Result[T] {
+	var last Result[T]
+	for _, r := range results {
+		if r.ok {
+			return r
+		}
+		last = r
+	}
+	return last
+}
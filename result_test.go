@@ -0,0 +1,154 @@
+package ask
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	ok := Try(strconv.Atoi("42"))
+	bad := Try(strconv.Atoi("x"))
+
+	if v, err := ok.Get(); v != 42 || err != nil || !ok.IsOk() || ok.IsErr() || ok.Err() != nil {
+		t.Errorf("Try(42) = %v, %v", v, err)
+	}
+	if _, err := bad.Get(); err == nil || bad.IsOk() || !bad.IsErr() {
+		t.Errorf("Try(x) error = %v, want error", err)
+	}
+	// The zero Result was never built and must not pass for a success
+	var zero Result[int]
+	if zero.IsOk() || !zero.IsErr() || !errors.Is(zero.Err(), ErrEmptyResult) || zero.UnwrapOr(1) != 1 {
+		t.Errorf("zero Result = %v, want failed with ErrEmptyResult", zero)
+	}
+	if zero == Ok(0) || If(zero, "ok", "failed") != "failed" {
+		t.Error("zero Result treated as Ok(0)")
+	}
+	if r := Err[int](nil); r != zero {
+		t.Errorf("Err(nil) = %v, want the zero Result", r)
+	}
+
+	if ok.UnwrapOr(1) != 42 || bad.UnwrapOr(1) != 1 {
+		t.Errorf("UnwrapOr() = %d, %d", ok.UnwrapOr(1), bad.UnwrapOr(1))
+	}
+	called := false
+	if got := ok.UnwrapOrElse(func(error) int { called = true; return 1 }); got != 42 || called {
+		t.Errorf("Ok.UnwrapOrElse() = %d, called %v; want 42 without calling fn", got, called)
+	}
+	if got := bad.UnwrapOrElse(func(err error) int { return len(err.Error()) }); got == 0 {
+		t.Error("Err.UnwrapOrElse() did not receive the error")
+	}
+
+	var gotV int
+	var gotErr error
+	ok.Match(func(v int) { gotV = v }, func(err error) { gotErr = err })
+	bad.Match(func(v int) { gotV = -1 }, func(err error) { gotErr = err })
+	if gotV != 42 || gotErr == nil {
+		t.Errorf("Match() = %d, %v", gotV, gotErr)
+	}
+
+	if ok.String() != "Ok(42)" || Err[int](errors.New("boom")).String() != "Err(boom)" {
+		t.Errorf("String() = %q, %q", ok.String(), Err[int](errors.New("boom")).String())
+	}
+}
+
+func TestResultUnwrap(t *testing.T) {
+	if got := Ok("x").Unwrap(); got != "x" {
+		t.Errorf("Ok.Unwrap() = %q", got)
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Unwrap() panic = %v, want wrapped fs.ErrNotExist", err)
+		}
+	}()
+	Try(os.ReadFile("testdata/does-not-exist")).Unwrap()
+	t.Error("Err.Unwrap() did not panic")
+}
+
+type resultErr struct{ code int }
+
+func (e *resultErr) Error() string { return "code " + strconv.Itoa(e.code) }
+
+func TestResultErrors(t *testing.T) {
+	r := Try(os.ReadFile("testdata/does-not-exist"))
+	if !r.Is(fs.ErrNotExist) || !errors.Is(r.Err(), fs.ErrNotExist) {
+		t.Errorf("Is(fs.ErrNotExist) = false for %v", r)
+	}
+	var pe *fs.PathError
+	if !r.As(&pe) || pe.Op != "open" {
+		t.Errorf("As(*fs.PathError) = %v", pe)
+	}
+	if Ok(1).Is(fs.ErrNotExist) || Ok(1).As(&pe) {
+		t.Error("Ok Result matches an error")
+	}
+
+	var typedNil *resultErr
+	if r := Try(1, typedNil); !r.IsOk() {
+		t.Errorf("Try(1, typed nil) = %v, want Ok", r)
+	}
+	var re *resultErr
+	if r := Err[int](&resultErr{code: 7}); !r.As(&re) || re.code != 7 {
+		t.Errorf("As(*resultErr) = %v", re)
+	}
+}
+
+func TestMapResult(t *testing.T) {
+	double := func(n int) int { return n * 2 }
+	if got := MapResult(Ok(21), double); got != Ok(42) {
+		t.Errorf("MapResult(Ok) = %v", got)
+	}
+	boom := errors.New("boom")
+	if got := MapResult(Err[int](boom), strconv.Itoa); got.Err() != boom {
+		t.Errorf("MapResult(Err) = %v", got)
+	}
+
+	parse := func(s string) Result[int] { return Try(strconv.Atoi(s)) }
+	if got := AndThen(Ok("12"), parse); got != Ok(12) {
+		t.Errorf("AndThen(Ok(12)) = %v", got)
+	}
+	if got := AndThen(Ok("x"), parse); got.IsOk() {
+		t.Errorf("AndThen(Ok(x)) = %v, want error", got)
+	}
+	called := false
+	if got := AndThen(Err[string](boom), func(string) Result[int] { called = true; return Ok(1) }); got.Err() != boom || called {
+		t.Errorf("AndThen(Err) = %v, called %v", got, called)
+	}
+}
+
+func TestResultConditions(t *testing.T) {
+	boom := errors.New("boom")
+	if got := If(Ok(0), "ok", "failed"); got != "ok" {
+		t.Errorf("If(Ok(0)) = %q, want ok", got)
+	}
+	if got := If(Err[int](boom), "ok", "failed"); got != "failed" {
+		t.Errorf("If(Err) = %q, want failed", got)
+	}
+	if got := Coalesce(Err[int](boom), Ok(0), Ok(5)); got != Ok(0) {
+		t.Errorf("Coalesce(Err, Ok(0), Ok(5)) = %v, want Ok(0)", got)
+	}
+	// Coalesce of failed Results falls back to the zero Result, which still fails
+	if got := Coalesce(Err[int](boom), Err[int](boom)); got.IsOk() {
+		t.Errorf("Coalesce(Err, Err) = %v, want a failed Result", got)
+	}
+	if !IsZero(Err[string](boom)) || IsZero(Ok("")) {
+		t.Error("IsZero does not follow Result.IsZero")
+	}
+}
+
+func TestCoalesceResult(t *testing.T) {
+	e1, e2 := errors.New("e1"), errors.New("e2")
+	if got := CoalesceResult(Err[int](e1), Ok(0), Ok(5)); got != Ok(0) {
+		t.Errorf("CoalesceResult(Err, Ok(0), Ok(5)) = %v, want Ok(0)", got)
+	}
+	got := CoalesceResult(Err[int](e1), Err[int](e2))
+	if got.IsOk() || got.Err() != e2 {
+		t.Errorf("CoalesceResult(Err(e1), Err(e2)) = %v, want Err(e2)", got)
+	}
+	if got := CoalesceResult[int](); got.IsOk() || !got.Is(ErrEmptyResult) {
+		t.Errorf("CoalesceResult() = %v, want the zero Result", got)
+	}
+}
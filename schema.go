@@ -0,0 +1,366 @@
+package ask
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/crazykun/ask/internal/structs"
+)
+
+// RefFormat selects the output of Reference.
+//
+// RefFormat Reference 的输出格式
+type RefFormat uint8
+
+const (
+	// Markdown renders a GitHub-flavored Markdown table.
+	// Markdown 表格
+	Markdown RefFormat = iota
+	// PlainText renders a column-aligned text table.
+	// 对齐的纯文本表格
+	PlainText
+)
+
+// String returns the name of the format.
+func (f RefFormat) String() string {
+	switch f {
+	case Markdown:
+		return "Markdown"
+	case PlainText:
+		return "PlainText"
+	}
+	return "RefFormat(" + strconv.Itoa(int(f)) + ")"
+}
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// Schema describes the struct type of v (a struct, or a possibly nil pointer to one)
+// as a JSON Schema (draft 2020-12) document. Properties use their json tag names in
+// declaration order and carry:
+//
+//   - the JSON type of the field, with "format": "date-time" for time.Time
+//   - "description" from the `desc:"..."` tag
+//   - "default" from the `default:"..."` tag, parsed as for ApplyDefaults and encoded
+//     as JSON, so a time.Duration default of "30s" becomes 30000000000
+//   - the object's "required" list from `ask:"required"` tags
+//
+// Nested structs become nested objects; embedded structs and struct pointers without
+// a json name are flattened, as in encoding/json. Types implementing
+// encoding.TextMarshaler are strings.
+//
+//	data, err := ask.Schema(Config{})
+//
+// Schema 根据结构体标签（default、desc、ask:"required"）生成 JSON Schema（draft 2020-12）
+func Schema(v any) ([]byte, error) {
+	t, err := structType(v, "Schema")
+	if err != nil {
+		return nil, err
+	}
+	s, err := objectSchema(t, "", map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = "https://json-schema.org/draft/2020-12/schema"
+	s.Title = t.Name()
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// Reference renders a table documenting every field of the struct type of v: its JSON
+// path, Go type, `env` tag, `default` tag, whether it is `ask:"required"`, and its
+// `desc` tag. Nested structs and pointers to structs are expanded into their fields,
+// except those set as a single value such as encoding.TextUnmarshaler implementations;
+// fields tagged json:"-" are listed only when they have an env tag.
+//
+//	| Field | Type | Env | Default | Required | Description |
+//	|---|---|---|---|---|---|
+//	| `server.port` | int | `APP_PORT` | `8080` | yes | listen port |
+//
+// Reference 根据结构体标签生成 Markdown 或纯文本格式的配置说明表
+func Reference(v any, format RefFormat) (string, error) {
+	t, err := structType(v, "Reference")
+	if err != nil {
+		return "", err
+	}
+	var rows [][6]string
+	referenceRows(t, FieldPath{}, map[reflect.Type]bool{}, &rows)
+
+	var b strings.Builder
+	switch format {
+	case Markdown:
+		b.WriteString("| Field | Type | Env | Default | Required | Description |\n")
+		b.WriteString("|---|---|---|---|---|---|\n")
+		for _, r := range rows {
+			b.WriteString("| " + strings.Join([]string{
+				code(r[0]), mdEscape(r[1]), code(r[2]), code(r[3]), r[4], mdEscape(r[5]),
+			}, " | ") + " |\n")
+		}
+	case PlainText:
+		tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "FIELD\tTYPE\tENV\tDEFAULT\tREQUIRED\tDESCRIPTION")
+		for _, r := range rows {
+			for i := range r {
+				r[i] = Ifelse(r[i], "-")
+			}
+			fmt.Fprintln(tw, strings.Join(r[:], "\t"))
+		}
+		tw.Flush()
+	default:
+		return "", fmt.Errorf("ask: Reference: unknown format %v", format)
+	}
+	return b.String(), nil
+}
+
+// structType returns the struct type of v, which may be a struct or a pointer to one.
+func structType(v any, fn string) (reflect.Type, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("ask: %s requires a struct or a pointer to one, got %T", fn, v)
+	}
+	return t, nil
+}
+
+// jsonSchema is one JSON Schema node. Fields are declared in the order they are written.
+type jsonSchema struct {
+	Schema               string          `json:"$schema,omitempty"`
+	Title                string          `json:"title,omitempty"`
+	Type                 any             `json:"type,omitempty"`
+	Format               string          `json:"format,omitempty"`
+	Description          string          `json:"description,omitempty"`
+	Default              json.RawMessage `json:"default,omitempty"`
+	Minimum              *int            `json:"minimum,omitempty"`
+	Items                *jsonSchema     `json:"items,omitempty"`
+	Properties           *schemaProps    `json:"properties,omitempty"`
+	AdditionalProperties *jsonSchema     `json:"additionalProperties,omitempty"`
+	Required             []string        `json:"required,omitempty"`
+}
+
+// schemaProps keeps properties in declaration order, which a map would lose.
+type schemaProps struct {
+	names   []string
+	schemas []*jsonSchema
+}
+
+func (p *schemaProps) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, name := range p.names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		b.Write(key)
+		b.WriteByte(':')
+		s, err := json.Marshal(p.schemas[i])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(s)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// objectSchema describes a struct type; prefix is the dotted Go path of the struct,
+// used in errors. active holds the struct types on the current path, so recursive
+// types end in a plain object instead of recursing forever.
+func objectSchema(t reflect.Type, prefix string, active map[reflect.Type]bool) (*jsonSchema, error) {
+	s := &jsonSchema{Type: "object", Properties: &schemaProps{}}
+	if active[t] {
+		s.Properties = nil
+		return s, nil
+	}
+	active[t] = true
+	defer delete(active, t)
+
+	if err := addProperties(s, t, prefix, active); err != nil {
+		return nil, err
+	}
+	if len(s.Properties.names) == 0 {
+		s.Properties = nil
+	}
+	return s, nil
+}
+
+func addProperties(s *jsonSchema, t reflect.Type, prefix string, active map[reflect.Type]bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("json") == "-" {
+			continue
+		}
+		name, hasJSON := jsonName(f)
+		path := prefix + f.Name
+		if et, ok := embeddedStruct(f, hasJSON); ok {
+			if active[et] {
+				continue
+			}
+			active[et] = true
+			err := addProperties(s, et, path+".", active)
+			delete(active, et)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		p, err := typeSchema(f.Type, path, active)
+		if err != nil {
+			return err
+		}
+		p.Description = f.Tag.Get("desc")
+		if def, ok := f.Tag.Lookup("default"); ok && def != "" {
+			v := reflect.New(f.Type).Elem()
+			if err := setString(v, def); err != nil {
+				return &FieldError{Field: path, Err: err}
+			}
+			if p.Default, err = json.Marshal(v.Interface()); err != nil {
+				return &FieldError{Field: path, Err: err}
+			}
+		}
+		s.Properties.names = append(s.Properties.names, name)
+		s.Properties.schemas = append(s.Properties.schemas, p)
+		if askTag(f).has("required") {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return nil
+}
+
+// embeddedStruct returns the struct type of f if f is embedded without a json name,
+// by value or by pointer, so that its fields are flattened into the parent like
+// encoding/json does.
+func embeddedStruct(f reflect.StructField, hasJSON bool) (reflect.Type, bool) {
+	if !f.Anonymous || hasJSON {
+		return nil, false
+	}
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct
+}
+
+// typeSchema describes how a value of type t, found at the dotted Go path, is encoded
+// by encoding/json.
+func typeSchema(t reflect.Type, path string, active map[reflect.Type]bool) (*jsonSchema, error) {
+	switch {
+	case t == timeType:
+		return &jsonSchema{Type: "string", Format: "date-time"}, nil
+	case t.Kind() != reflect.Ptr && (t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)):
+		return &jsonSchema{Type: "string"}, nil
+	case t.Kind() != reflect.Ptr && t.Implements(jsonMarshalerType):
+		// The encoding is up to the type, such as Option[T]; accept any value
+		return &jsonSchema{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &jsonSchema{Type: "string"}, nil
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &jsonSchema{Type: "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		zero := 0
+		return &jsonSchema{Type: "integer", Minimum: &zero}, nil
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}, nil
+	case reflect.Ptr:
+		s, err := typeSchema(t.Elem(), path, active)
+		if err != nil {
+			return nil, err
+		}
+		if typ, ok := s.Type.(string); ok {
+			s.Type = []string{typ, "null"}
+		}
+		return s, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return &jsonSchema{Type: "string"}, nil // base64, like encoding/json
+		}
+		items, err := typeSchema(t.Elem(), path, active)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		values, err := typeSchema(t.Elem(), path, active)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return objectSchema(t, path+".", active)
+	case reflect.Interface:
+		return &jsonSchema{}, nil
+	}
+	return nil, &FieldError{Field: path, Err: fmt.Errorf("unsupported type %v", t)}
+}
+
+// referenceRows appends one row per leaf field of t.
+func referenceRows(t reflect.Type, prefix FieldPath, active map[reflect.Type]bool, rows *[][6]string) {
+	active[t] = true
+	defer delete(active, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("json") == "-" && f.Tag.Get("env") == "" {
+			continue
+		}
+		name, hasJSON := jsonName(f)
+		if et, ok := embeddedStruct(f, hasJSON); ok {
+			if !active[et] {
+				referenceRows(et, prefix, active, rows)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		path := FieldPath{Go: joinPath(prefix.Go, f.Name), JSON: joinPath(prefix.JSON, name)}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && !structs.IsLeaf(ft) && !active[ft] {
+			referenceRows(ft, path, active, rows)
+			continue
+		}
+
+		*rows = append(*rows, [6]string{
+			path.JSON,
+			f.Type.String(),
+			f.Tag.Get("env"),
+			f.Tag.Get("default"),
+			If(askTag(f).has("required"), "yes", ""),
+			f.Tag.Get("desc"),
+		})
+	}
+}
+
+// code wraps s in backticks for Markdown, leaving empty cells empty.
+func code(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + strings.ReplaceAll(s, "|", `\|`) + "`"
+}
+
+func mdEscape(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,229 @@
+package ask
+
+import (
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type schemaDB struct {
+	Host string `json:"host" env:"DB_HOST" default:"localhost" desc:"database host"`
+	Port uint16 `json:"port" env:"DB_PORT" default:"5432"`
+}
+
+type schemaBase struct {
+	ID string `json:"id" ask:"required"`
+}
+
+type schemaNode struct {
+	Name string      `json:"name"`
+	Next *schemaNode `json:"next"`
+}
+
+type schemaConfig struct {
+	schemaBase
+	Name    string            `json:"name" env:"APP_NAME" ask:"required" desc:"application | service name"`
+	Debug   bool              `json:"debug"`
+	Ratio   float64           `json:"ratio" default:"0.5"`
+	Timeout time.Duration     `json:"timeout" default:"30s" desc:"request timeout"`
+	Since   time.Time         `json:"since"`
+	Addr    netip.Addr        `json:"addr" default:"127.0.0.1"`
+	Tags    []string          `json:"tags" default:"a,b"`
+	Labels  map[string]string `json:"labels"`
+	Raw     []byte            `json:"raw"`
+	Extra   any               `json:"extra"`
+	Avatar  Option[string]    `json:"avatar,omitzero"`
+	DB      schemaDB          `json:"db"`
+	Cache   *schemaDB         `json:"cache,omitempty"`
+	Node    schemaNode        `json:"node"`
+	Secret  string            `json:"-" env:"APP_SECRET"`
+	Ignored string            `json:"-"`
+	private string
+}
+
+func TestSchema(t *testing.T) {
+	data, err := Schema((*schemaConfig)(nil))
+	if err != nil {
+		t.Fatalf("Schema() error = %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Schema() is not valid JSON: %v\n%s", err, data)
+	}
+	if doc["$schema"] != "https://json-schema.org/draft/2020-12/schema" || doc["title"] != "schemaConfig" || doc["type"] != "object" {
+		t.Errorf("Schema() header = %v, %v, %v", doc["$schema"], doc["title"], doc["type"])
+	}
+	if got := doc["required"]; !reflect.DeepEqual(got, []any{"id", "name"}) {
+		t.Errorf("required = %v, want [id name]", got)
+	}
+
+	// Properties keep declaration order; embedded fields are flattened and json:"-" skipped
+	props := doc["properties"].(map[string]any)
+	if len(props) != 15 {
+		t.Errorf("properties = %v, want 15", reflect.ValueOf(props).MapKeys())
+	}
+	body := string(data)
+	if i, j := strings.Index(body, `"id": {`), strings.Index(body, `"node": {`); i < 0 || i > j {
+		t.Error("properties are not in declaration order")
+	}
+
+	tests := []struct {
+		name string
+		want map[string]any
+	}{
+		{"name", map[string]any{"type": "string", "description": "application | service name"}},
+		{"debug", map[string]any{"type": "boolean"}},
+		{"ratio", map[string]any{"type": "number", "default": 0.5}},
+		{"timeout", map[string]any{"type": "integer", "default": float64(30 * time.Second), "description": "request timeout"}},
+		{"since", map[string]any{"type": "string", "format": "date-time"}},
+		{"addr", map[string]any{"type": "string", "default": "127.0.0.1"}},
+		{"tags", map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "default": []any{"a", "b"}}},
+		{"labels", map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}}},
+		{"raw", map[string]any{"type": "string"}},
+		{"extra", map[string]any{}},
+		{"avatar", map[string]any{}},
+	}
+	for _, tt := range tests {
+		if got := props[tt.name]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("properties[%s] = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	db := props["db"].(map[string]any)
+	port := db["properties"].(map[string]any)["port"]
+	if !reflect.DeepEqual(port, map[string]any{"type": "integer", "minimum": 0.0, "default": 5432.0}) {
+		t.Errorf("db.port = %v", port)
+	}
+	if cache := props["cache"].(map[string]any); !reflect.DeepEqual(cache["type"], []any{"object", "null"}) {
+		t.Errorf("cache.type = %v, want [object null]", cache["type"])
+	}
+	next := props["node"].(map[string]any)["properties"].(map[string]any)["next"]
+	if !reflect.DeepEqual(next, map[string]any{"type": []any{"object", "null"}}) {
+		t.Errorf("node.next = %v, want recursion cut off", next)
+	}
+}
+
+func TestSchemaEmbeddedPointer(t *testing.T) {
+	type request struct {
+		*schemaBase
+		Name string `json:"name" env:"APP_NAME"`
+	}
+
+	data, err := Schema(request{})
+	if err != nil {
+		t.Fatalf("Schema() error = %v", err)
+	}
+	var doc struct {
+		Properties map[string]any `json:"properties"`
+		Required   []string       `json:"required"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc.Properties["id"]; !ok || len(doc.Properties) != 2 || !reflect.DeepEqual(doc.Required, []string{"id"}) {
+		t.Errorf("Schema() = %s; want id flattened into the parent", data)
+	}
+
+	txt, err := Reference(request{}, PlainText)
+	if err != nil {
+		t.Fatalf("Reference() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(txt), "\n")
+	if len(lines) != 3 || strings.Fields(lines[1])[0] != "id" || strings.Fields(lines[2])[0] != "name" {
+		t.Errorf("Reference() =\n%s\nwant rows id and name", txt)
+	}
+}
+
+func TestSchemaErrors(t *testing.T) {
+	if _, err := Schema(42); err == nil {
+		t.Error("Schema(42) error = nil, want error")
+	}
+	if _, err := Schema(struct{ C chan int }{}); err == nil {
+		t.Error("Schema(chan field) error = nil, want error")
+	}
+	if _, err := Schema(struct {
+		N int `default:"abc"`
+	}{}); err == nil {
+		t.Error("Schema(bad default) error = nil, want error")
+	}
+
+	// Errors name the dotted path of nested fields, as ApplyDefaults does
+	type inner struct {
+		Port int `default:"abc"`
+	}
+	type outer struct {
+		DB []*inner
+	}
+	var fe *FieldError
+	if _, err := Schema(outer{}); !errors.As(err, &fe) || fe.Field != "DB.Port" {
+		t.Errorf("Schema(nested bad default) error = %v, want field DB.Port", err)
+	}
+	if err := ApplyDefaults(&struct{ DB inner }{}); !errors.As(err, &fe) || fe.Field != "DB.Port" {
+		t.Errorf("ApplyDefaults(nested bad default) error = %v, want field DB.Port", err)
+	}
+}
+
+func TestReference(t *testing.T) {
+	type config struct {
+		Name    string        `json:"name" env:"APP_NAME" ask:"required" desc:"service | name"`
+		Timeout time.Duration `json:"timeout" default:"30s"`
+		DB      *schemaDB     `json:"db"`
+		Backup  textEndpoint  `json:"backup" env:"BACKUP_ADDR"`
+		Secret  string        `json:"-" env:"APP_SECRET"`
+		Ignored string        `json:"-"`
+	}
+
+	md, err := Reference(config{}, Markdown)
+	if err != nil {
+		t.Fatalf("Reference(Markdown) error = %v", err)
+	}
+	wantMD := "| Field | Type | Env | Default | Required | Description |\n" +
+		"|---|---|---|---|---|---|\n" +
+		"| `name` | string | `APP_NAME` |  | yes | service \\| name |\n" +
+		"| `timeout` | time.Duration |  | `30s` |  |  |\n" +
+		"| `db.host` | string | `DB_HOST` | `localhost` |  | database host |\n" +
+		"| `db.port` | uint16 | `DB_PORT` | `5432` |  |  |\n" +
+		"| `backup` | ask.textEndpoint | `BACKUP_ADDR` |  |  |  |\n" +
+		"| `Secret` | string | `APP_SECRET` |  |  |  |\n"
+	if md != wantMD {
+		t.Errorf("Reference(Markdown) =\n%s\nwant\n%s", md, wantMD)
+	}
+
+	txt, err := Reference(&config{}, PlainText)
+	if err != nil {
+		t.Fatalf("Reference(PlainText) error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(txt), "\n")
+	want := [][]string{
+		{"FIELD", "TYPE", "ENV", "DEFAULT", "REQUIRED", "DESCRIPTION"},
+		{"name", "string", "APP_NAME", "-", "yes", "service", "|", "name"},
+		{"timeout", "time.Duration", "-", "30s", "-", "-"},
+		{"db.host", "string", "DB_HOST", "localhost", "-", "database", "host"},
+		{"db.port", "uint16", "DB_PORT", "5432", "-", "-"},
+		{"backup", "ask.textEndpoint", "BACKUP_ADDR", "-", "-", "-"},
+		{"Secret", "string", "APP_SECRET", "-", "-", "-"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("Reference(PlainText) =\n%s", txt)
+	}
+	for i, w := range want {
+		if got := strings.Fields(lines[i]); !reflect.DeepEqual(got, w) {
+			t.Errorf("line %d = %q, want %q", i, got, w)
+		}
+	}
+
+	if _, err := Reference(config{}, RefFormat(9)); err == nil {
+		t.Error("Reference(unknown format) error = nil, want error")
+	}
+	if _, err := Reference("x", Markdown); err == nil {
+		t.Error("Reference(string) error = nil, want error")
+	}
+	if RefFormat(9).String() != "RefFormat(9)" || Markdown.String() != "Markdown" {
+		t.Errorf("RefFormat.String() = %q, %q", RefFormat(9), Markdown)
+	}
+}
@@ -0,0 +1,164 @@
+package ask
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// ErrUnknownKey is wrapped by the errors a strict Lookup reports for keys it does not contain.
+var ErrUnknownKey = errors.New("unknown key")
+
+// Switcher is a value-matching switch expression started by Switch. The first
+// matching case wins and later cases are skipped:
+//
+//	label := ask.Switch[State, string](order.State).
+//		Case(StateShipped, "已发货").
+//		CaseIn([]State{StatePaid, StatePacked}, "待发货").
+//		CaseFunc(State.IsFinal, "已结束").
+//		Default("未知")
+//
+// V cannot be inferred from the key, so both type arguments are spelled out.
+// Switcher is a small value type and does not allocate.
+//
+// Switcher 按值匹配的 switch 表达式，由 Switch 开始，第一个匹配的分支生效
+type Switcher[K comparable, V any] struct {
+	key  K
+	val  V
+	done bool
+}
+
+// Switch starts a switch expression on key.
+//
+// Switch 以 key 开始 switch 表达式
+func Switch[K comparable, V any](key K) Switcher[K, V] {
+	return Switcher[K, V]{key: key}
+}
+
+// Case yields v if the key equals k and no earlier case matched.
+//
+// Case 键等于 k 时结果为 v
+func (s Switcher[K, V]) Case(k K, v V) Switcher[K, V] {
+	if s.done || s.key != k {
+		return s
+	}
+	return Switcher[K, V]{key: s.key, val: v, done: true}
+}
+
+// CaseIn yields v if the key equals any of keys and no earlier case matched.
+//
+// CaseIn 键属于 keys 之一时结果为 v
+func (s Switcher[K, V]) CaseIn(keys []K, v V) Switcher[K, V] {
+	if s.done {
+		return s
+	}
+	for _, k := range keys {
+		if s.key == k {
+			return Switcher[K, V]{key: s.key, val: v, done: true}
+		}
+	}
+	return s
+}
+
+// CaseFunc yields v if pred reports true for the key and no earlier case matched.
+// pred is not called once a case matched.
+//
+// CaseFunc pred(key) 为 true 时结果为 v
+func (s Switcher[K, V]) CaseFunc(pred func(K) bool, v V) Switcher[K, V] {
+	if s.done || !pred(s.key) {
+		return s
+	}
+	return Switcher[K, V]{key: s.key, val: v, done: true}
+}
+
+// Default ends the switch, returning the value of the matching case, or v if none matched.
+//
+// Default 结束 switch 表达式，没有分支匹配时返回 v
+func (s Switcher[K, V]) Default(v V) V {
+	if s.done {
+		return s.val
+	}
+	return v
+}
+
+// Value ends the switch, returning the value of the matching case and whether one matched.
+//
+// Value 结束 switch 表达式，返回匹配分支的值以及是否匹配
+func (s Switcher[K, V]) Value() (V, bool) {
+	return s.val, s.done
+}
+
+// Lookup is a precompiled, read-only table mapping keys to values, with a default for
+// keys it does not contain. Build it once, typically as a package-level variable,
+// and share it: it copies its map on construction and is never modified afterwards,
+// so it is safe for concurrent use.
+//
+//	var orderLabels = ask.NewLookup(map[State]string{
+//		StatePaid:    "待发货",
+//		StateShipped: "已发货",
+//	}, "未知")
+//
+//	label := orderLabels.Get(order.State)
+//
+// Lookup 预先构建的只读映射表，带默认值，可安全地并发读取
+type Lookup[K comparable, V any] struct {
+	m      map[K]V
+	def    V
+	strict bool
+}
+
+// NewLookup returns a Lookup holding a copy of m, returning def for unknown keys.
+// Later changes to m do not affect the Lookup.
+//
+// NewLookup 复制 m 构建映射表，未知键返回 def
+func NewLookup[K comparable, V any](m map[K]V, def V) *Lookup[K, V] {
+	return &Lookup[K, V]{m: maps.Clone(m), def: def}
+}
+
+// Strict returns a copy of l whose Resolve reports unknown keys as errors wrapping
+// ErrUnknownKey instead of returning the default. Get is unaffected.
+//
+// Strict 返回严格模式的副本，Resolve 对未知键返回错误
+func (l *Lookup[K, V]) Strict() *Lookup[K, V] {
+	return &Lookup[K, V]{m: l.m, def: l.def, strict: true}
+}
+
+// Get returns the value for k, or the default if l does not contain k.
+//
+// Get 返回 k 对应的值，未知键返回默认值
+func (l *Lookup[K, V]) Get(k K) V {
+	if v, ok := l.m[k]; ok {
+		return v
+	}
+	return l.def
+}
+
+// Resolve returns the value for k. For unknown keys it returns the default, or,
+// if l is strict, the default and an error wrapping ErrUnknownKey:
+//
+//	label, err := orderLabels.Strict().Resolve(state)
+//	if errors.Is(err, ask.ErrUnknownKey) { ... }
+//
+// Resolve 返回 k 对应的值；严格模式下未知键返回错误
+func (l *Lookup[K, V]) Resolve(k K) (V, error) {
+	if v, ok := l.m[k]; ok {
+		return v, nil
+	}
+	if l.strict {
+		return l.def, fmt.Errorf("ask: lookup %v: %w", k, ErrUnknownKey)
+	}
+	return l.def, nil
+}
+
+// Has reports whether l contains k.
+//
+// Has 判断映射表是否包含 k
+func (l *Lookup[K, V]) Has(k K) bool {
+	_, ok := l.m[k]
+	return ok
+}
+
+// Len returns the number of keys in l.
+func (l *Lookup[K, V]) Len() int {
+	return len(l.m)
+}
@@ -0,0 +1,130 @@
+package ask
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+type orderState int
+
+const (
+	statePending orderState = iota
+	statePaid
+	statePacked
+	stateShipped
+	stateCancelled
+	stateRefunded
+)
+
+func (s orderState) final() bool { return s >= stateCancelled }
+
+func stateLabel(s orderState) string {
+	return Switch[orderState, string](s).
+		Case(stateShipped, "已发货").
+		CaseIn([]orderState{statePaid, statePacked}, "待发货").
+		CaseFunc(orderState.final, "已结束").
+		Default("待支付")
+}
+
+func TestSwitch(t *testing.T) {
+	for s, want := range map[orderState]string{
+		statePending:   "待支付",
+		statePaid:      "待发货",
+		statePacked:    "待发货",
+		stateShipped:   "已发货",
+		stateCancelled: "已结束",
+		stateRefunded:  "已结束",
+	} {
+		if got := stateLabel(s); got != want {
+			t.Errorf("stateLabel(%d) = %q, want %q", s, got, want)
+		}
+	}
+
+	// The first matching case wins and later predicates are not called
+	called := false
+	got := Switch[string, int]("a").
+		Case("a", 1).
+		Case("a", 2).
+		CaseFunc(func(string) bool { called = true; return true }, 3).
+		Default(0)
+	if got != 1 || called {
+		t.Errorf("Switch() = %d, called %v; want 1 without calling pred", got, called)
+	}
+
+	if v, ok := Switch[int, string](5).Case(1, "one").CaseIn(nil, "none").Value(); ok || v != "" {
+		t.Errorf("Value() = %q, %v; want \"\", false", v, ok)
+	}
+	if v, ok := Switch[int, string](1).Case(1, "").Value(); !ok || v != "" {
+		t.Errorf("Value() = %q, %v; want \"\", true", v, ok)
+	}
+}
+
+func TestSwitchNoAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = stateLabel(statePacked)
+	})
+	if allocs != 0 {
+		t.Errorf("Switch allocated %v times per run; want 0", allocs)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	m := map[orderState]string{statePaid: "待发货", stateShipped: "已发货"}
+	labels := NewLookup(m, "未知")
+	m[statePaid] = "changed"
+	m[statePending] = "added"
+
+	if got := labels.Get(statePaid); got != "待发货" {
+		t.Errorf("Get(paid) = %q, want 待发货 (map must be copied)", got)
+	}
+	if got := labels.Get(statePending); got != "未知" {
+		t.Errorf("Get(pending) = %q, want default", got)
+	}
+	if !labels.Has(stateShipped) || labels.Has(statePending) || labels.Len() != 2 {
+		t.Errorf("Has/Len = %v, %v, %d", labels.Has(stateShipped), labels.Has(statePending), labels.Len())
+	}
+
+	if v, err := labels.Resolve(statePending); err != nil || v != "未知" {
+		t.Errorf("Resolve(pending) = %q, %v; want default, nil", v, err)
+	}
+
+	strict := labels.Strict()
+	if v, err := strict.Resolve(stateShipped); err != nil || v != "已发货" {
+		t.Errorf("strict Resolve(shipped) = %q, %v", v, err)
+	}
+	v, err := strict.Resolve(statePending)
+	if !errors.Is(err, ErrUnknownKey) || v != "未知" {
+		t.Errorf("strict Resolve(pending) = %q, %v; want default, ErrUnknownKey", v, err)
+	}
+	if err != nil && err.Error() != "ask: lookup 0: unknown key" {
+		t.Errorf("error = %q", err)
+	}
+	if strict.Get(statePending) != "未知" {
+		t.Error("strict Get(pending) != default")
+	}
+	if _, err := labels.Resolve(statePending); err != nil {
+		t.Error("Strict() modified the original Lookup")
+	}
+
+	empty := NewLookup[string, int](nil, -1)
+	if empty.Get("x") != -1 || empty.Len() != 0 {
+		t.Errorf("NewLookup(nil).Get() = %d", empty.Get("x"))
+	}
+}
+
+func TestLookupConcurrent(t *testing.T) {
+	labels := NewLookup(map[int]string{1: "one", 2: "two"}, "many").Strict()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := 0; k < 1000; k++ {
+				labels.Get(k % 4)
+				_, _ = labels.Resolve(k % 4)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,26 @@
+package ask
+
+import (
+	"reflect"
+
+	"github.com/crazykun/ask/internal/structs"
+)
+
+// tagOptions holds the comma-separated options of an `ask:"..."` struct tag,
+// for example `ask:"required,append"`.
+type tagOptions []string
+
+// askTag returns the options of the ask tag on f.
+func askTag(f reflect.StructField) tagOptions {
+	return structs.Options(f)
+}
+
+// has reports whether opt is one of the options.
+func (o tagOptions) has(opt string) bool {
+	for _, v := range o {
+		if v == opt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package ask
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/crazykun/ask/internal/structs"
+)
+
+// ErrRequired is wrapped by the *FieldError values Require reports for missing fields.
+var ErrRequired = errors.New("required value is missing")
+
+// FieldPath identifies a struct field by its dotted path, both as Go field names
+// ("Server.Host") and as JSON names taken from the json tag ("server.host").
+// Fields without a json name use their Go name in the JSON path, and embedded
+// structs without a json name are flattened, like encoding/json does. Fields tagged
+// json:"-", and the fields below them, are not encoded and have an empty JSON path.
+//
+// FieldPath 字段路径，同时提供 Go 字段名路径和 JSON 标签路径
+type FieldPath struct {
+	Go   string
+	JSON string
+}
+
+// String returns the JSON path, which is what API clients see, or the Go path for
+// fields that have no JSON path.
+func (p FieldPath) String() string {
+	if p.JSON == "" {
+		return p.Go
+	}
+	return p.JSON
+}
+
+// ZeroFields lists every field of the struct v (or *v) that is zero or empty under
+// IsZero and IsEmpty. Nested structs and non-nil pointers to structs are descended
+// into and report their own zero fields; a nil pointer is reported as one field.
+// Structs with their own IsZero method, such as time.Time, and encoding.TextUnmarshaler
+// implementations count as single fields.
+// ZeroFields returns nil if v is not a struct or a non-nil pointer to one.
+//
+// ZeroFields 列出结构体中所有零值或空值字段的路径
+func ZeroFields(v any) []FieldPath {
+	rv, ok := structValue(v)
+	if !ok {
+		return nil
+	}
+	var paths []FieldPath
+	walkFields(rv, FieldPath{}, func(f reflect.StructField, fv reflect.Value, path FieldPath) bool {
+		if fv.Kind() == reflect.Struct && !structs.IsLeaf(fv.Type()) {
+			return true
+		}
+		if isMissing(fv) {
+			paths = append(paths, path)
+			return false
+		}
+		return true
+	})
+	return paths
+}
+
+// Require checks that every field tagged `ask:"required"` in the struct v (or *v)
+// is neither zero nor empty, using the same rules as ZeroFields. Required fields
+// inside nested structs are checked as well, but an optional nil pointer to a struct
+// is not descended into. All missing fields are reported together: the result joins
+// one *FieldError per field with errors.Join, each naming the field by its path as
+// FieldPath.String gives it and wrapping ErrRequired.
+//
+//	type SignupRequest struct {
+//		Email string `json:"email" ask:"required"`
+//		Name  string `json:"name"`
+//	}
+//
+// Require 校验带 ask:"required" 标签的字段均非零值，缺失字段通过 errors.Join 汇总返回
+func Require(v any) error {
+	rv, ok := structValue(v)
+	if !ok {
+		return fmt.Errorf("ask: Require requires a struct or a non-nil pointer to one, got %T", v)
+	}
+	var errs []error
+	walkFields(rv, FieldPath{}, func(f reflect.StructField, fv reflect.Value, path FieldPath) bool {
+		if askTag(f).has("required") && isMissing(fv) {
+			errs = append(errs, &FieldError{Field: path.String(), Err: ErrRequired})
+			return false
+		}
+		return true
+	})
+	return errors.Join(errs...)
+}
+
+// isMissing reports whether a field value is zero or empty.
+func isMissing(fv reflect.Value) bool {
+	x := fv.Interface()
+	return IsZero(x) || IsEmpty(x)
+}
+
+// structValue returns the struct v holds or points to.
+func structValue(v any) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	return rv, rv.Kind() == reflect.Struct
+}
+
+// walkFields calls visit for every exported field of rv, depth first. When visit returns
+// true and the field is a struct (or a non-nil pointer to one) that is not a leaf,
+// its fields are walked too. Pointers already on the current path are not followed again.
+func walkFields(rv reflect.Value, prefix FieldPath, visit func(reflect.StructField, reflect.Value, FieldPath) bool) {
+	walkStruct(rv, prefix, map[uintptr]bool{}, visit)
+}
+
+func walkStruct(rv reflect.Value, prefix FieldPath, active map[uintptr]bool, visit func(reflect.StructField, reflect.Value, FieldPath) bool) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fv := rv.Field(i)
+		if !f.IsExported() {
+			// Exported fields of an embedded unexported struct are promoted, as in encoding/json
+			if f.Anonymous && fv.Kind() == reflect.Struct {
+				walkStruct(fv, prefix, active, visit)
+			}
+			continue
+		}
+		name, hasJSON := jsonName(f)
+
+		skipped := f.Tag.Get("json") == "-"
+		path := prefix
+		if !f.Anonymous || hasJSON || skipped {
+			path = FieldPath{Go: joinPath(prefix.Go, f.Name), JSON: joinPath(prefix.JSON, name)}
+		}
+		// Below the root only fields left out of JSON have an empty JSON path
+		if skipped || (prefix.Go != "" && prefix.JSON == "") {
+			path.JSON = ""
+		}
+
+		if !visit(f, fv, path) {
+			continue
+		}
+
+		switch {
+		case fv.Kind() == reflect.Struct && !structs.IsLeaf(fv.Type()):
+			walkStruct(fv, path, active, visit)
+		case fv.Kind() == reflect.Ptr && !fv.IsNil() && fv.Elem().Kind() == reflect.Struct && !structs.IsLeaf(fv.Type().Elem()):
+			p := fv.Pointer()
+			if active[p] {
+				continue
+			}
+			active[p] = true
+			walkStruct(fv.Elem(), path, active, visit)
+			delete(active, p)
+		}
+	}
+}
+
+// jsonName returns the name encoding/json uses for f, and whether the json tag set one.
+func jsonName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return f.Name, false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return f.Name, false
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
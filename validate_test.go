@@ -0,0 +1,136 @@
+package ask
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type validateAddress struct {
+	City string `json:"city" ask:"required"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type validateMeta struct {
+	Source string `json:"source"`
+}
+
+type validateRequest struct {
+	validateMeta
+	Email    string            `json:"email" ask:"required"`
+	Name     string            `json:"name"`
+	Age      int               `json:"age"`
+	Tags     []string          `json:"tags" ask:"required"`
+	Address  validateAddress   `json:"address"`
+	Billing  *validateAddress  `json:"billing,omitempty"`
+	Shipping *validateAddress  `json:"shipping" ask:"required"`
+	Created  time.Time         `json:"created_at"`
+	Extra    map[string]string `json:"-"`
+	internal string
+}
+
+func TestZeroFields(t *testing.T) {
+	req := validateRequest{
+		Email:   "a@example.com",
+		Tags:    []string{},
+		Address: validateAddress{City: "Hangzhou"},
+		Billing: &validateAddress{Zip: "310000"},
+	}
+
+	var gotJSON, gotGo []string
+	for _, p := range ZeroFields(&req) {
+		gotJSON = append(gotJSON, p.JSON)
+		gotGo = append(gotGo, p.Go)
+	}
+
+	wantJSON := []string{"source", "name", "age", "tags", "address.zip", "billing.city", "shipping", "created_at", ""}
+	wantGo := []string{"Source", "Name", "Age", "Tags", "Address.Zip", "Billing.City", "Shipping", "Created", "Extra"}
+	if !reflect.DeepEqual(gotJSON, wantJSON) {
+		t.Errorf("ZeroFields JSON paths = %v; want %v", gotJSON, wantJSON)
+	}
+	if !reflect.DeepEqual(gotGo, wantGo) {
+		t.Errorf("ZeroFields Go paths = %v; want %v", gotGo, wantGo)
+	}
+
+	if got := ZeroFields(42); got != nil {
+		t.Errorf("ZeroFields(42) = %v; want nil", got)
+	}
+}
+
+func TestZeroFieldsSkippedJSON(t *testing.T) {
+	type request struct {
+		Name  string          `json:"name"`
+		Local validateAddress `json:"-"`
+	}
+	got := ZeroFields(request{Name: "a", Local: validateAddress{Zip: "310000"}})
+	if want := []FieldPath{{Go: "Local.City"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ZeroFields() = %#v; want %#v", got, want)
+	}
+	if got[0].String() != "Local.City" {
+		t.Errorf("String() = %q; want the Go path", got[0].String())
+	}
+	if err := Require(request{}); err == nil || err.Error() != "ask: field Local.City: required value is missing" {
+		t.Errorf("Require() error = %v; want Local.City missing", err)
+	}
+}
+
+func TestZeroFieldsTextLeaf(t *testing.T) {
+	type upstream struct {
+		Name string       `json:"name"`
+		Addr textEndpoint `json:"addr" ask:"required"`
+	}
+	got := ZeroFields(upstream{Name: "api", Addr: textEndpoint{Host: "db"}})
+	if len(got) != 0 {
+		t.Errorf("ZeroFields() = %v; want none, a partly set TextUnmarshaler is set", got)
+	}
+	if err := Require(upstream{}); err == nil || err.Error() != "ask: field addr: required value is missing" {
+		t.Errorf("Require() error = %v; want addr missing", err)
+	}
+}
+
+func TestRequire(t *testing.T) {
+	err := Require(validateRequest{Tags: []string{}})
+	if err == nil {
+		t.Fatal("Require() error = nil; want missing fields")
+	}
+	if !errors.Is(err, ErrRequired) {
+		t.Errorf("errors.Is(err, ErrRequired) = false")
+	}
+
+	var fields []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var fe *FieldError
+		if !errors.As(e, &fe) {
+			t.Fatalf("error %v is not a *FieldError", e)
+		}
+		fields = append(fields, fe.Field)
+	}
+	// Billing is an optional nil pointer, so its required City is not checked.
+	if want := []string{"email", "tags", "address.city", "shipping"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("missing fields = %v; want %v", fields, want)
+	}
+}
+
+func TestRequireValid(t *testing.T) {
+	req := &validateRequest{
+		Email:    "a@example.com",
+		Tags:     []string{"new"},
+		Address:  validateAddress{City: "Hangzhou"},
+		Shipping: &validateAddress{City: "Shanghai"},
+	}
+	if err := Require(req); err != nil {
+		t.Errorf("Require() error = %v; want nil", err)
+	}
+
+	req.Billing = &validateAddress{}
+	if err := Require(req); err == nil || err.Error() != "ask: field billing.city: required value is missing" {
+		t.Errorf("Require() error = %v; want billing.city missing", err)
+	}
+}
+
+func TestRequireInvalidTarget(t *testing.T) {
+	if err := Require("payload"); err == nil || errors.Is(err, ErrRequired) {
+		t.Errorf("Require(string) error = %v; want invalid target error", err)
+	}
+}